@@ -0,0 +1,171 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/avast/retry-go/v4"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// BroadcastMsgs signs msgs, in a single transaction, with the key keyName from the node's keyring,
+// and broadcasts the transaction through the node's gRPC server.
+// The transaction's gas is simulated and multiplied by the chain's gas adjustment,
+// and its fee is paid at the chain's gas prices.
+// BroadcastMsgs waits until the transaction is committed, and returns the committed transaction's response with its events.
+// An error is returned along with the response if the transaction failed.
+//
+// Unlike the transactions run through the chain's binary, BroadcastMsgs supports any message that the chain's app accepts.
+// Addresses in msgs must be encoded with the chain's Bech32 prefix.
+func (tn *ChainNode) BroadcastMsgs(ctx context.Context, keyName string, msgs ...types.Msg) (*types.TxResponse, error) {
+	cfg := tn.Chain.Config()
+
+	key, err := tn.Keybase().Key(keyName)
+	if err != nil {
+		return nil, fmt.Errorf("get key %s: %w", keyName, err)
+	}
+	address, err := types.Bech32ifyAddressBytes(cfg.Bech32Prefix, key.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := grpc.Dial(tn.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// Hold the lock until the transaction is committed, so that transactions from the node's keys use consecutive sequences.
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	accRes, err := authtypes.NewQueryClient(conn).Account(ctx, &authtypes.QueryAccountRequest{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("query account %s: %w", address, err)
+	}
+	var acc authtypes.AccountI
+	if err := defaultEncoding.InterfaceRegistry.UnpackAny(accRes.Account, &acc); err != nil {
+		return nil, fmt.Errorf("unpack account %s: %w", address, err)
+	}
+
+	txConfig := defaultEncoding.TxConfig
+	signMode := txConfig.SignModeHandler().DefaultMode()
+	builder := txConfig.NewTxBuilder()
+	if err := builder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+
+	// Simulate with an empty signature, which carries the signer's public key and sequence.
+	sig := signing.SignatureV2{
+		PubKey:   key.GetPubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: acc.GetSequence(),
+	}
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	txbz, err := txConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("encode tx: %w", err)
+	}
+	txClient := txtypes.NewServiceClient(conn)
+	simRes, err := txClient.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: txbz})
+	if err != nil {
+		return nil, fmt.Errorf("simulate tx: %w", err)
+	}
+
+	gas := uint64(math.Ceil(float64(simRes.GasInfo.GasUsed) * cfg.GasAdjustment))
+	fees, err := gasFees(cfg.GasPrices, gas)
+	if err != nil {
+		return nil, err
+	}
+	builder.SetGasLimit(gas)
+	builder.SetFeeAmount(fees)
+
+	if err := tn.signTx(keyName, builder, sig, authsigning.SignerData{
+		ChainID:       cfg.ChainID,
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+	}); err != nil {
+		return nil, err
+	}
+	txbz, err = txConfig.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("encode tx: %w", err)
+	}
+
+	bcRes, err := txClient.BroadcastTx(ctx, &txtypes.BroadcastTxRequest{TxBytes: txbz, Mode: txtypes.BroadcastMode_BROADCAST_MODE_SYNC})
+	if err != nil {
+		return nil, fmt.Errorf("broadcast tx: %w", err)
+	}
+	if bcRes.TxResponse.Code != 0 {
+		return bcRes.TxResponse, fmt.Errorf("transaction failed check with code %d: %s", bcRes.TxResponse.Code, bcRes.TxResponse.RawLog)
+	}
+
+	var res *types.TxResponse
+	err = retry.Do(func() error {
+		getRes, err := txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: bcRes.TxResponse.TxHash})
+		if err != nil {
+			return err
+		}
+		res = getRes.TxResponse
+		return nil
+	}, retry.Context(ctx), retry.Attempts(10), retry.Delay(time.Duration(blockTime)*time.Second), retry.DelayType(retry.FixedDelay))
+	if err != nil {
+		return nil, fmt.Errorf("tx %s was not committed: %w", bcRes.TxResponse.TxHash, err)
+	}
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction failed with code %d: %s", res.Code, res.RawLog)
+	}
+	return res, nil
+}
+
+// signTx replaces the signature sig in builder with a signature, by the key keyName from the node's keyring, of the built transaction.
+// The transaction is signed directly, rather than through the SDK's client, which resolves signers with the SDK's global Bech32 prefix.
+func (tn *ChainNode) signTx(keyName string, builder client.TxBuilder, sig signing.SignatureV2, signerData authsigning.SignerData) error {
+	data, ok := sig.Data.(*signing.SingleSignatureData)
+	if !ok {
+		return errors.New("signature must have single signature data")
+	}
+	signBytes, err := defaultEncoding.TxConfig.SignModeHandler().GetSignBytes(data.SignMode, signerData, builder.GetTx())
+	if err != nil {
+		return fmt.Errorf("get sign bytes: %w", err)
+	}
+	signature, _, err := tn.Keybase().Sign(keyName, signBytes)
+	if err != nil {
+		return fmt.Errorf("sign tx: %w", err)
+	}
+	sig.Data = &signing.SingleSignatureData{SignMode: data.SignMode, Signature: signature}
+	return builder.SetSignatures(sig)
+}
+
+// gasFees returns the fees for gas at gasPrices, such as "0.01uatom", rounded up to whole coins.
+func gasFees(gasPrices string, gas uint64) (types.Coins, error) {
+	prices, err := types.ParseDecCoins(gasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("parse gas prices %q: %w", gasPrices, err)
+	}
+	limit := types.NewDec(int64(gas))
+	fees := make([]types.Coin, len(prices))
+	for i, p := range prices {
+		fees[i] = types.NewCoin(p.Denom, p.Amount.Mul(limit).Ceil().RoundInt())
+	}
+	// Zero fees are left out, as a transaction's fees must be positive.
+	return types.NewCoins(fees...), nil
+}
+
+// BroadcastMsgs broadcasts msgs in a single transaction signed by keyName through the chain's full node.
+// See ChainNode.BroadcastMsgs.
+func (c *CosmosChain) BroadcastMsgs(ctx context.Context, keyName string, msgs ...types.Msg) (*types.TxResponse, error) {
+	return c.getFullNode().BroadcastMsgs(ctx, keyName, msgs...)
+}
@@ -0,0 +1,56 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_BroadcastMsgs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	// Use a chain whose Bech32 prefix differs from the SDK's default.
+	osmosis, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "osmosis", Version: "v7.2.0", ChainConfig: ibc.ChainConfig{ChainID: "osmosis-1001"}})
+
+	users := ibctest.GetAndFundTestUsers(t, ctx, "user", 100_000_000, osmosis, osmosis)
+	prefix := osmosis.Config().Bech32Prefix
+	denom := osmosis.Config().Denom
+	from, to := users[0].Bech32Address(prefix), users[1].Bech32Address(prefix)
+
+	t.Run("send", func(t *testing.T) {
+		res, err := osmosis.BroadcastMsgs(ctx, users[0].KeyName,
+			&banktypes.MsgSend{FromAddress: from, ToAddress: to, Amount: types.NewCoins(types.NewInt64Coin(denom, 1_000))},
+			&banktypes.MsgSend{FromAddress: from, ToAddress: to, Amount: types.NewCoins(types.NewInt64Coin(denom, 2_000))},
+		)
+		require.NoError(t, err)
+		require.NotEmpty(t, res.TxHash)
+
+		var transfers int
+		for _, e := range res.Events {
+			if e.Type == banktypes.EventTypeTransfer {
+				transfers++
+			}
+		}
+		// Each message makes a transfer, in addition to any transfer paying the fee.
+		require.GreaterOrEqual(t, transfers, 2)
+
+		bal, err := osmosis.GetBalance(ctx, to, denom)
+		require.NoError(t, err)
+		require.EqualValues(t, 100_000_000+3_000, bal)
+	})
+
+	t.Run("failed message", func(t *testing.T) {
+		_, err := osmosis.BroadcastMsgs(ctx, users[0].KeyName,
+			&banktypes.MsgSend{FromAddress: from, ToAddress: to, Amount: types.NewCoins(types.NewInt64Coin(denom, 1_000_000_000))},
+		)
+		require.Error(t, err)
+	})
+}
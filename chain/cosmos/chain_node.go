@@ -15,9 +15,12 @@ import (
 
 	"github.com/avast/retry-go/v4"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	ibctm "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
 	dockerclient "github.com/docker/docker/client"
@@ -61,6 +64,9 @@ type ChainNode struct {
 // ChainNodes is a collection of ChainNode
 type ChainNodes []*ChainNode
 
+// BondDenom is the denom that validators bond at genesis, and that is delegated to validators.
+const BondDenom = "stake"
+
 const (
 	valKey      = "validator"
 	blockTime   = 2 // seconds
@@ -443,6 +449,244 @@ func (tn *ChainNode) ExecThenWaitForBlocks(ctx context.Context, command []string
 	return test.WaitForBlocks(ctx, 2, tn)
 }
 
+// CosmosTx is the output of a transaction broadcast with the chain binary.
+type CosmosTx struct {
+	TxHash string `json:"txhash"`
+	Code   int    `json:"code"`
+	RawLog string `json:"raw_log"`
+}
+
+// execTx broadcasts the transaction built by the tx subcommand command from keyName,
+// waits for it to be committed, and returns its hash.
+func (tn *ChainNode) execTx(ctx context.Context, keyName string, command ...string) (string, error) {
+	command = append([]string{tn.Chain.Config().Bin, "tx"}, command...)
+	command = append(command,
+		"--gas-prices", tn.Chain.Config().GasPrices,
+		"--gas-adjustment", fmt.Sprint(tn.Chain.Config().GasAdjustment),
+		"--from", keyName,
+		"--keyring-backend", keyring.BackendTest,
+		"--node", fmt.Sprintf("tcp://%s:26657", tn.HostName()),
+		"--output", "json",
+		"-y",
+		"--home", tn.HomeDir(),
+		"--chain-id", tn.Chain.Config().ChainID,
+	)
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+	stdout, _, err := tn.Exec(ctx, command, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := test.WaitForBlocks(ctx, 2, tn); err != nil {
+		return "", fmt.Errorf("wait for blocks: %w", err)
+	}
+	output := CosmosTx{}
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return "", err
+	}
+	if output.Code != 0 {
+		return output.TxHash, fmt.Errorf("transaction failed with code %d: %s", output.Code, output.RawLog)
+	}
+	return output.TxHash, nil
+}
+
+// writeTempFile writes b to a new file in the node's home directory, which is mounted in the containers running commands,
+// and returns the file's path in the container and a function to remove the file.
+func (tn *ChainNode) writeTempFile(pattern string, b []byte) (string, func(), error) {
+	f, err := os.CreateTemp(tn.Dir(), pattern)
+	if err != nil {
+		return "", nil, err
+	}
+	remove := func() { _ = os.Remove(f.Name()) }
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		remove()
+		return "", nil, err
+	}
+	if err := f.Close(); err != nil {
+		remove()
+		return "", nil, err
+	}
+	return filepath.Join(tn.HomeDir(), filepath.Base(f.Name())), remove, nil
+}
+
+// TextProposal is a governance proposal with no effect other than recording its passing.
+type TextProposal struct {
+	Deposit     string // Deposit with denom, such as 10000000stake.
+	Title       string
+	Description string
+}
+
+// ParamChangeProposal is a governance proposal to change module parameters.
+type ParamChangeProposal struct {
+	Deposit     string        `json:"deposit"` // Deposit with denom, such as 10000000stake.
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Changes     []ParamChange `json:"changes"`
+}
+
+// ParamChange changes the parameter Key of the module parameter Subspace, such as "staking", to Value.
+// Value must marshal to the JSON the module expects for the parameter;
+// for example, durations are strings of nanoseconds, such as "600000000000".
+type ParamChange struct {
+	Subspace string      `json:"subspace"`
+	Key      string      `json:"key"`
+	Value    interface{} `json:"value"`
+}
+
+// SoftwareUpgradeProposal is a governance proposal to upgrade the chain's software at a block height.
+type SoftwareUpgradeProposal struct {
+	Deposit     string // Deposit with denom, such as 10000000stake.
+	Title       string
+	Name        string // Name of the upgrade plan, which must match an upgrade handler in the new binary.
+	Description string
+	Height      uint64 // Height at which the chain halts for the upgrade.
+	Info        string // Optional upgrade info, such as binary download links.
+}
+
+// Options for voting on a governance proposal.
+const (
+	ProposalVoteYes        = "yes"
+	ProposalVoteNo         = "no"
+	ProposalVoteNoWithVeto = "no_with_veto"
+	ProposalVoteAbstain    = "abstain"
+)
+
+// TextProposal submits a text governance proposal from keyName, and returns the transaction hash.
+func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
+	return tn.execTx(ctx, keyName,
+		"gov", "submit-proposal",
+		"--type", "Text",
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	)
+}
+
+// ParamChangeProposal submits a parameter change governance proposal from keyName, and returns the transaction hash.
+func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop ParamChangeProposal) (string, error) {
+	propbz, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	propPath, remove, err := tn.writeTempFile("param-change-proposal-*.json", propbz)
+	if err != nil {
+		return "", err
+	}
+	defer remove()
+
+	return tn.execTx(ctx, keyName,
+		"gov", "submit-proposal", "param-change", propPath,
+	)
+}
+
+// UpgradeProposal submits a software upgrade governance proposal from keyName, and returns the transaction hash.
+func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
+	command := []string{
+		"gov", "submit-proposal",
+		"software-upgrade", prop.Name,
+		"--upgrade-height", strconv.FormatUint(prop.Height, 10),
+		"--title", prop.Title,
+		"--description", prop.Description,
+		"--deposit", prop.Deposit,
+	}
+	if prop.Info != "" {
+		command = append(command, "--upgrade-info", prop.Info)
+	}
+	return tn.execTx(ctx, keyName, command...)
+}
+
+// DepositOnProposal deposits amount, with denom such as 10000000stake, on the proposal with proposalID from keyName.
+func (tn *ChainNode) DepositOnProposal(ctx context.Context, keyName string, proposalID uint64, amount string) error {
+	_, err := tn.execTx(ctx, keyName,
+		"gov", "deposit",
+		strconv.FormatUint(proposalID, 10), amount,
+	)
+	return err
+}
+
+// VoteOnProposal casts vote, one of the ProposalVote options, on the proposal with proposalID from keyName.
+func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID uint64, vote string) error {
+	_, err := tn.execTx(ctx, keyName,
+		"gov", "vote",
+		strconv.FormatUint(proposalID, 10), vote,
+	)
+	return err
+}
+
+// UpdateClient updates the light client with clientID using header, from keyName, rather than with a header fetched by a relayer.
+func (tn *ChainNode) UpdateClient(ctx context.Context, keyName string, clientID string, header *ibctm.Header) error {
+	headerbz, err := codec.NewProtoCodec(defaultEncoding.InterfaceRegistry).MarshalInterfaceJSON(header)
+	if err != nil {
+		return fmt.Errorf("marshal header: %w", err)
+	}
+
+	headerPath, remove, err := tn.writeTempFile("header-*.json", headerbz)
+	if err != nil {
+		return err
+	}
+	defer remove()
+
+	_, err = tn.execTx(ctx, keyName,
+		"ibc", "client", "update", clientID, headerPath,
+	)
+	return err
+}
+
+// OperatorAddress returns the operator address, such as cosmosvaloper1..., of the validator whose key is in the node's keyring.
+func (tn *ChainNode) OperatorAddress() (string, error) {
+	key, err := tn.GetKey(valKey)
+	if err != nil {
+		return "", err
+	}
+	return types.Bech32ifyAddressBytes(tn.Chain.Config().Bech32Prefix+"valoper", key.GetAddress().Bytes())
+}
+
+// Delegate delegates amount, with denom such as 10000000stake, from keyName to the validator with operator address validatorAddr.
+func (tn *ChainNode) Delegate(ctx context.Context, keyName string, validatorAddr string, amount string) error {
+	_, err := tn.execTx(ctx, keyName,
+		"staking", "delegate", validatorAddr, amount,
+	)
+	return err
+}
+
+// Redelegate moves amount of keyName's delegation from the validator srcValidatorAddr to the validator dstValidatorAddr.
+func (tn *ChainNode) Redelegate(ctx context.Context, keyName string, srcValidatorAddr, dstValidatorAddr string, amount string) error {
+	_, err := tn.execTx(ctx, keyName,
+		"staking", "redelegate", srcValidatorAddr, dstValidatorAddr, amount,
+	)
+	return err
+}
+
+// Unbond unbonds amount of keyName's delegation to the validator with operator address validatorAddr.
+func (tn *ChainNode) Unbond(ctx context.Context, keyName string, validatorAddr string, amount string) error {
+	_, err := tn.execTx(ctx, keyName,
+		"staking", "unbond", validatorAddr, amount,
+	)
+	return err
+}
+
+// CreateValidator makes the node a validator, self-delegating selfDelegation from the validator key in the node's keyring.
+func (tn *ChainNode) CreateValidator(ctx context.Context, selfDelegation types.Coin) error {
+	stdout, _, err := tn.Exec(ctx, []string{tn.Chain.Config().Bin, "tendermint", "show-validator", "--home", tn.HomeDir()}, nil)
+	if err != nil {
+		return fmt.Errorf("show validator public key: %w", err)
+	}
+
+	_, err = tn.execTx(ctx, valKey,
+		"staking", "create-validator",
+		"--amount", selfDelegation.String(),
+		"--pubkey", strings.TrimSpace(string(stdout)),
+		"--moniker", CondenseMoniker(tn.Name()),
+		"--commission-rate", "0.1",
+		"--commission-max-rate", "0.2",
+		"--commission-max-change-rate", "0.01",
+		"--min-self-delegation", "1",
+	)
+	return err
+}
+
 type InstantiateContractAttribute struct {
 	Value string `json:"value"`
 }
@@ -735,6 +979,35 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}, retry.Context(ctx), retry.Attempts(40), retry.Delay(3*time.Second), retry.DelayType(retry.FixedDelay))
 }
 
+// StopContainer stops the node's container, giving the node 30 seconds to shut down gracefully.
+func (tn *ChainNode) StopContainer(ctx context.Context) error {
+	timeout := 30 * time.Second
+	return tn.DockerClient.ContainerStop(ctx, tn.containerID, &timeout)
+}
+
+// RemoveContainer removes the node's stopped container.
+// The node's home directory is bind mounted from the host, so it is kept for a new container to use.
+func (tn *ChainNode) RemoveContainer(ctx context.Context) error {
+	err := tn.DockerClient.ContainerRemove(ctx, tn.containerID, dockertypes.ContainerRemoveOptions{
+		RemoveVolumes: true,
+	})
+	if err != nil {
+		return err
+	}
+	tn.containerID = ""
+	return nil
+}
+
+// DisconnectNetwork disconnects the node's container from the network with ID networkID.
+func (tn *ChainNode) DisconnectNetwork(ctx context.Context, networkID string) error {
+	return dockerutil.DisconnectContainer(ctx, tn.DockerClient, networkID, tn.containerID)
+}
+
+// ConnectNetwork connects the node's container to the network with ID networkID.
+func (tn *ChainNode) ConnectNetwork(ctx context.Context, networkID string) error {
+	return dockerutil.ConnectContainer(ctx, tn.DockerClient, networkID, tn.containerID)
+}
+
 // InitValidatorFiles creates the node files and signs a genesis transaction
 func (tn *ChainNode) InitValidatorFiles(
 	ctx context.Context,
@@ -854,6 +1127,11 @@ func (tn *ChainNode) RegisterICA(ctx context.Context, address, connectionID stri
 
 	stdout, _, err := tn.Exec(ctx, command, nil)
 	if err != nil {
+		// Interchain accounts are registered through the intertx module of the interchain accounts demo,
+		// which most chain binaries do not include.
+		if strings.Contains(err.Error(), `unknown command "intertx"`) {
+			return "", fmt.Errorf("%w: %v", ibc.ErrInterchainAccountsNotSupported, err)
+		}
 		return "", err
 	}
 	output := IBCTransferTx{}
@@ -2,6 +2,7 @@ package cosmos
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -14,19 +15,27 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	bankTypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	chanTypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	ibctm "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
 	dockertypes "github.com/docker/docker/api/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/ibctest/chain/internal/tendermint"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
 	"github.com/strangelove-ventures/ibctest/test"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// validatorFeeFunds is the amount of the chain's denom given to a validator created by CreateValidator to pay transaction fees.
+const validatorFeeFunds = 10_000_000
+
 type CosmosChain struct {
 	testName      string
 	cfg           ibc.ChainConfig
@@ -34,6 +43,18 @@ type CosmosChain struct {
 	numFullNodes  int
 	ChainNodes    ChainNodes
 
+	// Number of nodes added after the chain was initialized, to give each added node a unique index.
+	addedNodes int
+
+	// Copy of a validator on an isolated network, started by ConflictingHeaders.
+	doubleSigner *ChainNode
+
+	// Nodes disconnected from the chain's network by DisconnectNode.
+	disconnectedNodes map[*ChainNode]bool
+
+	// Groups of validators isolated from the chain's network by PartitionValidators.
+	partitions []*partitionGroup
+
 	log *zap.Logger
 }
 
@@ -80,12 +101,46 @@ func (c *CosmosChain) Config() ibc.ChainConfig {
 
 // Implements Chain interface
 func (c *CosmosChain) Initialize(testName string, homeDirectory string, cli *client.Client, networkID string) error {
+	if c.cfg.LocalImage != nil {
+		image, err := c.buildLocalImage(context.TODO(), cli)
+		if err != nil {
+			return fmt.Errorf("build local image for chain %s: %w", c.cfg.ChainID, err)
+		}
+		c.cfg.Images = append([]ibc.DockerImage{image}, c.cfg.Images[1:]...)
+	}
 	c.initializeChainNodes(testName, homeDirectory, cli, networkID)
 	return nil
 }
 
+// localImageRepository is the repository of images built from c.cfg.LocalImage.
+const localImageRepository = "ibctest-local"
+
+// buildLocalImage builds the image described by c.cfg.LocalImage, unless it was already built from the same inputs.
+func (c *CosmosChain) buildLocalImage(ctx context.Context, cli *client.Client) (ibc.DockerImage, error) {
+	build := c.cfg.LocalImage
+	var (
+		tag string
+		err error
+	)
+	switch {
+	case build.Binary != "":
+		tag, err = dockerutil.BuildBinaryImage(ctx, cli, localImageRepository, c.cfg.Images[0].Ref(), build.Binary, c.cfg.Bin)
+	case build.ContextDir != "":
+		tag, err = dockerutil.BuildImage(ctx, cli, localImageRepository, build.ContextDir, build.Dockerfile)
+	default:
+		return ibc.DockerImage{}, errors.New("local image requires a binary or a build context directory")
+	}
+	if err != nil {
+		return ibc.DockerImage{}, err
+	}
+	c.log.Info("Using local image", zap.String("image", localImageRepository+":"+tag))
+	return ibc.DockerImage{Repository: localImageRepository, Version: tag}, nil
+}
+
 func (c *CosmosChain) getFullNode() *ChainNode {
-	if len(c.ChainNodes) > c.numValidators {
+	// Nodes added by AddFullNode are appended after the configured nodes,
+	// and are only used when the caller asks for them.
+	if c.numFullNodes > 0 {
 		// use first full node
 		return c.ChainNodes[c.numValidators]
 	}
@@ -271,22 +326,12 @@ func (c *CosmosChain) initializeChainNodes(
 	var chainNodes []*ChainNode
 	count := c.numValidators + c.numFullNodes
 	chainCfg := c.Config()
-	for _, image := range chainCfg.Images {
-		rc, err := cli.ImagePull(
-			context.TODO(),
-			image.Repository+":"+image.Version,
-			dockertypes.ImagePullOptions{},
-		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
+	for i, image := range chainCfg.Images {
+		if i == 0 && chainCfg.LocalImage != nil {
+			// The image was built locally, and cannot be pulled.
+			continue
 		}
+		c.pullImage(context.TODO(), cli, image)
 	}
 	for i := 0; i < count; i++ {
 		tn := &ChainNode{
@@ -299,6 +344,7 @@ func (c *CosmosChain) initializeChainNodes(
 			NetworkID:    networkID,
 			TestName:     testName,
 			Image:        chainCfg.Images[0],
+			Validator:    i < c.numValidators,
 		}
 		tn.MkDir()
 		chainNodes = append(chainNodes, tn)
@@ -306,6 +352,32 @@ func (c *CosmosChain) initializeChainNodes(
 	c.ChainNodes = chainNodes
 }
 
+// nextNodeIndex returns the index of a node added to the chain after it was initialized.
+func (c *CosmosChain) nextNodeIndex() int {
+	i := c.numValidators + c.numFullNodes + c.addedNodes
+	c.addedNodes++
+	return i
+}
+
+// pullImage pulls image, logging rather than returning failures, as the image may already be present locally.
+func (c *CosmosChain) pullImage(ctx context.Context, cli *client.Client, image ibc.DockerImage) {
+	rc, err := cli.ImagePull(
+		ctx,
+		image.Repository+":"+image.Version,
+		dockertypes.ImagePullOptions{},
+	)
+	if err != nil {
+		c.log.Error("Failed to pull image",
+			zap.Error(err),
+			zap.String("repository", image.Repository),
+			zap.String("tag", image.Version),
+		)
+		return
+	}
+	_, _ = io.Copy(io.Discard, rc)
+	_ = rc.Close()
+}
+
 type GenesisValidatorPubKey struct {
 	Type  string `json:"type"`
 	Value string `json:"value"`
@@ -337,12 +409,12 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	genesisStakeAmount := types.Coin{
 		Amount: types.NewInt(1000000000000),
-		Denom:  "stake",
+		Denom:  BondDenom,
 	}
 
 	genesisSelfDelegation := types.Coin{
 		Amount: types.NewInt(100000000000),
-		Denom:  "stake",
+		Denom:  BondDenom,
 	}
 
 	genesisAmounts := []types.Coin{genesisAmount, genesisStakeAmount}
@@ -412,12 +484,38 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		return err
 	}
 
+	if len(chainCfg.GenesisModifications) > 0 {
+		genbz, err = modifyGenesisKVs(genbz, chainCfg.GenesisModifications)
+		if err != nil {
+			return err
+		}
+	}
+
+	if chainCfg.ModifyGenesis != nil {
+		genbz, err = chainCfg.ModifyGenesis(chainCfg, genbz)
+		if err != nil {
+			return fmt.Errorf("failed to modify genesis file: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(validator0.GenesisFilePath(), genbz, 0644); err != nil { //nolint
+		return err
+	}
+
 	for i := 1; i < len(c.ChainNodes); i++ {
 		if err := os.WriteFile(c.ChainNodes[i].GenesisFilePath(), genbz, 0644); err != nil { //nolint
 			return err
 		}
 	}
 
+	if interval := c.cfg.SnapshotInterval; interval > 0 {
+		for _, v := range validators {
+			if err := v.SetSnapshotInterval(interval); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := c.ChainNodes.LogGenesisHashes(); err != nil {
 		return err
 	}
@@ -477,6 +575,398 @@ func (c *CosmosChain) QueryInterchainAccount(ctx context.Context, connectionID,
 	return c.getFullNode().QueryICA(ctx, connectionID, address)
 }
 
+// TextProposal submits a text governance proposal from keyName, and returns the proposal's ID.
+func (c *CosmosChain) TextProposal(ctx context.Context, keyName string, prop TextProposal) (uint64, error) {
+	txHash, err := c.getFullNode().TextProposal(ctx, keyName, prop)
+	if err != nil {
+		return 0, fmt.Errorf("submit text proposal: %w", err)
+	}
+	return c.proposalID(txHash)
+}
+
+// ParamChangeProposal submits a parameter change governance proposal from keyName, and returns the proposal's ID.
+func (c *CosmosChain) ParamChangeProposal(ctx context.Context, keyName string, prop ParamChangeProposal) (uint64, error) {
+	txHash, err := c.getFullNode().ParamChangeProposal(ctx, keyName, prop)
+	if err != nil {
+		return 0, fmt.Errorf("submit param change proposal: %w", err)
+	}
+	return c.proposalID(txHash)
+}
+
+// UpgradeProposal submits a software upgrade governance proposal from keyName, and returns the proposal's ID.
+func (c *CosmosChain) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (uint64, error) {
+	txHash, err := c.getFullNode().UpgradeProposal(ctx, keyName, prop)
+	if err != nil {
+		return 0, fmt.Errorf("submit upgrade proposal: %w", err)
+	}
+	return c.proposalID(txHash)
+}
+
+// proposalID returns the ID of the proposal submitted by the transaction with txHash.
+func (c *CosmosChain) proposalID(txHash string) (uint64, error) {
+	txResp, err := c.getTransaction(txHash)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	id, ok := tendermint.AttributeValue(txResp.Events, govtypes.EventTypeSubmitProposal, govtypes.AttributeKeyProposalID)
+	if !ok {
+		return 0, fmt.Errorf("proposal id not found in events of transaction %s", txHash)
+	}
+	proposalID, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid proposal id %q in events of transaction %s: %w", id, txHash, err)
+	}
+	return proposalID, nil
+}
+
+// DepositOnProposal deposits amount, with denom such as 10000000stake, on the proposal with proposalID from keyName.
+func (c *CosmosChain) DepositOnProposal(ctx context.Context, keyName string, proposalID uint64, amount string) error {
+	return c.getFullNode().DepositOnProposal(ctx, keyName, proposalID, amount)
+}
+
+// VoteOnProposal casts vote, one of the ProposalVote options, on the proposal with proposalID from keyName.
+func (c *CosmosChain) VoteOnProposal(ctx context.Context, keyName string, proposalID uint64, vote string) error {
+	return c.getFullNode().VoteOnProposal(ctx, keyName, proposalID, vote)
+}
+
+// VoteOnProposalValidator casts vote on the proposal with proposalID from the key of the validator with validatorIndex.
+func (c *CosmosChain) VoteOnProposalValidator(ctx context.Context, validatorIndex int, proposalID uint64, vote string) error {
+	validators := c.validators()
+	if validatorIndex < 0 || validatorIndex >= len(validators) {
+		return fmt.Errorf("validator index %d out of range of %d validators", validatorIndex, len(validators))
+	}
+	return validators[validatorIndex].VoteOnProposal(ctx, valKey, proposalID, vote)
+}
+
+// VoteOnProposalAllValidators casts vote on the proposal with proposalID from every validator's key.
+func (c *CosmosChain) VoteOnProposalAllValidators(ctx context.Context, proposalID uint64, vote string) error {
+	var eg errgroup.Group
+	for _, n := range c.validators() {
+		n := n
+		eg.Go(func() error {
+			return n.VoteOnProposal(ctx, valKey, proposalID, vote)
+		})
+	}
+	return eg.Wait()
+}
+
+// QueryProposal returns the proposal with proposalID.
+// The proposal's content is unpacked, so its title, description and type are available through its getters.
+func (c *CosmosChain) QueryProposal(ctx context.Context, proposalID uint64) (*govtypes.Proposal, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := govtypes.NewQueryClient(conn).Proposal(ctx, &govtypes.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	if err := res.Proposal.UnpackInterfaces(defaultEncoding.InterfaceRegistry); err != nil {
+		return nil, fmt.Errorf("unpack proposal %d content: %w", proposalID, err)
+	}
+	return &res.Proposal, nil
+}
+
+// QueryProposalTally returns the tally of the votes on the proposal with proposalID.
+// The tally is final once the proposal's voting period has ended.
+func (c *CosmosChain) QueryProposalTally(ctx context.Context, proposalID uint64) (govtypes.TallyResult, error) {
+	grpcAddress := c.getFullNode().hostGRPCPort
+	conn, err := grpc.Dial(grpcAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return govtypes.TallyResult{}, err
+	}
+	defer conn.Close()
+
+	res, err := govtypes.NewQueryClient(conn).TallyResult(ctx, &govtypes.QueryTallyResultRequest{ProposalId: proposalID})
+	if err != nil {
+		return govtypes.TallyResult{}, err
+	}
+	return res.Tally, nil
+}
+
+// PollForProposalStatus polls the proposal with proposalID at each block, from the current height until maxHeight,
+// and returns the proposal once it has status.
+func (c *CosmosChain) PollForProposalStatus(ctx context.Context, maxHeight uint64, proposalID uint64, status govtypes.ProposalStatus) (*govtypes.Proposal, error) {
+	for {
+		prop, err := c.QueryProposal(ctx, proposalID)
+		if err != nil {
+			return nil, err
+		}
+		if prop.Status == status {
+			return prop, nil
+		}
+
+		height, err := c.Height(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if height >= maxHeight {
+			return nil, fmt.Errorf("proposal %d has status %s rather than %s at height %d", proposalID, prop.Status, status, height)
+		}
+		if err := test.WaitForBlocks(ctx, 1, c); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// UpdateClient implements ibc.ClientUpdatingChain.
+func (c *CosmosChain) UpdateClient(ctx context.Context, keyName, clientID string, header *ibctm.Header) error {
+	return c.getFullNode().UpdateClient(ctx, keyName, clientID, header)
+}
+
+// validators returns the chain's validator nodes.
+func (c *CosmosChain) validators() ChainNodes {
+	var validators ChainNodes
+	for _, n := range c.ChainNodes {
+		if n.Validator {
+			validators = append(validators, n)
+		}
+	}
+	return validators
+}
+
+// Delegate delegates amount, with denom such as 10000000stake, from keyName to the validator with operator address validatorAddr.
+func (c *CosmosChain) Delegate(ctx context.Context, keyName string, validatorAddr string, amount string) error {
+	return c.getFullNode().Delegate(ctx, keyName, validatorAddr, amount)
+}
+
+// Redelegate moves amount of keyName's delegation from the validator srcValidatorAddr to the validator dstValidatorAddr.
+func (c *CosmosChain) Redelegate(ctx context.Context, keyName string, srcValidatorAddr, dstValidatorAddr string, amount string) error {
+	return c.getFullNode().Redelegate(ctx, keyName, srcValidatorAddr, dstValidatorAddr, amount)
+}
+
+// Unbond unbonds amount of keyName's delegation to the validator with operator address validatorAddr.
+func (c *CosmosChain) Unbond(ctx context.Context, keyName string, validatorAddr string, amount string) error {
+	return c.getFullNode().Unbond(ctx, keyName, validatorAddr, amount)
+}
+
+// CreateValidator makes node, a full node of the chain, a validator bonding selfDelegation of BondDenom.
+// A validator key is created in the node's keyring and funded from the first validator,
+// with selfDelegation and tokens to pay the transaction fees.
+func (c *CosmosChain) CreateValidator(ctx context.Context, node *ChainNode, selfDelegation int64) error {
+	found := false
+	for _, n := range c.ChainNodes {
+		found = found || n == node
+	}
+	if !found {
+		return fmt.Errorf("node %s is not a node of chain %s", node.Name(), c.cfg.ChainID)
+	}
+	if node.Validator {
+		return fmt.Errorf("node %s is already a validator", node.Name())
+	}
+
+	if _, err := node.Keybase().Key(valKey); err != nil {
+		if err := node.CreateKey(ctx, valKey); err != nil {
+			return fmt.Errorf("create validator key: %w", err)
+		}
+	}
+	key, err := node.GetKey(valKey)
+	if err != nil {
+		return err
+	}
+	addr, err := types.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, key.GetAddress().Bytes())
+	if err != nil {
+		return err
+	}
+
+	funder := c.validators()[0]
+	for _, amount := range []ibc.WalletAmount{
+		{Address: addr, Denom: BondDenom, Amount: selfDelegation},
+		{Address: addr, Denom: c.cfg.Denom, Amount: validatorFeeFunds},
+	} {
+		if err := funder.SendFunds(ctx, valKey, amount); err != nil {
+			return fmt.Errorf("fund validator key with %s: %w", amount.Denom, err)
+		}
+	}
+
+	if err := node.CreateValidator(ctx, types.NewInt64Coin(BondDenom, selfDelegation)); err != nil {
+		return fmt.Errorf("create validator: %w", err)
+	}
+	node.Validator = true
+	return nil
+}
+
+// ValidatorSet returns the Tendermint validator set, with the voting power of each validator, at height.
+func (c *CosmosChain) ValidatorSet(ctx context.Context, height uint64) ([]*tmtypes.Validator, error) {
+	return validatorSet(ctx, c.getFullNode().Client, height)
+}
+
+// validatorSet returns the validator set at height from the node at client.
+func validatorSet(ctx context.Context, client rpcclient.Client, height uint64) ([]*tmtypes.Validator, error) {
+	var (
+		h          = int64(height)
+		perPage    = 100
+		validators []*tmtypes.Validator
+	)
+	for page := 1; ; page++ {
+		res, err := client.Validators(ctx, &h, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("tendermint rpc get validators at height %d: %w", height, err)
+		}
+		validators = append(validators, res.Validators...)
+		if len(res.Validators) == 0 || len(validators) >= res.Total {
+			return validators, nil
+		}
+	}
+}
+
+// Upgrade upgrades the running chain to the software in image.
+// It submits prop from keyName, votes for it with every validator,
+// waits for the chain to halt at the upgrade height,
+// and then restarts every node from image against its existing home directory.
+//
+// The proposal must pass within the chain's voting period before the upgrade height is reached,
+// so tests typically shorten the voting period with ChainConfig.GenesisModifications.
+func (c *CosmosChain) Upgrade(ctx context.Context, keyName string, prop SoftwareUpgradeProposal, image ibc.DockerImage) error {
+	proposalID, err := c.UpgradeProposal(ctx, keyName, prop)
+	if err != nil {
+		return err
+	}
+	if err := c.VoteOnProposalAllValidators(ctx, proposalID, ProposalVoteYes); err != nil {
+		return fmt.Errorf("vote on upgrade proposal %d: %w", proposalID, err)
+	}
+	if err := c.waitForHalt(ctx, prop.Height); err != nil {
+		return err
+	}
+	if err := c.UpgradeVersion(ctx, image); err != nil {
+		return err
+	}
+	// Wait for blocks past the upgrade height to ensure the upgraded chain is producing blocks.
+	return test.WaitForBlocks(ctx, 2, c.getFullNode())
+}
+
+// waitForHalt waits for the chain to halt at haltHeight.
+func (c *CosmosChain) waitForHalt(ctx context.Context, haltHeight uint64) error {
+	for {
+		height, err := c.Height(ctx)
+		if err != nil {
+			return fmt.Errorf("wait for halt at height %d: %w", haltHeight, err)
+		}
+		if height >= haltHeight {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+
+	// The chain stores the block at the upgrade height before halting while applying it,
+	// so allow a few block times for the halt before checking the chain has stopped.
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(3 * blockTime * time.Second):
+	}
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("wait for halt at height %d: %w", haltHeight, err)
+	}
+	if height > haltHeight {
+		return fmt.Errorf("chain did not halt at upgrade height %d, it is at height %d", haltHeight, height)
+	}
+	return nil
+}
+
+// UpgradeVersion replaces the container of every node with a container running image,
+// against the same home directory, and waits for the nodes to start.
+// The nodes should be halted, such as at an upgrade height, before calling UpgradeVersion.
+func (c *CosmosChain) UpgradeVersion(ctx context.Context, image ibc.DockerImage) error {
+	if c.cfg.NoHostMount {
+		return fmt.Errorf("cannot upgrade chain %s: upgrading requires node home directories to be mounted from the host", c.cfg.ChainID)
+	}
+
+	c.pullImage(ctx, c.ChainNodes[0].DockerClient, image)
+	c.cfg.Images = append([]ibc.DockerImage{image}, c.cfg.Images[1:]...)
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range c.ChainNodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.StopContainer(egCtx); err != nil {
+				return fmt.Errorf("stop container %s: %w", n.Name(), err)
+			}
+			if err := n.RemoveContainer(egCtx); err != nil {
+				return fmt.Errorf("remove container %s: %w", n.Name(), err)
+			}
+			n.Image = image
+			return n.CreateNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	c.log.Info("Starting upgraded containers", zap.String("chain_id", c.cfg.ChainID), zap.String("image", image.Ref()))
+	return c.StartAllNodes(ctx)
+}
+
+// node returns the node at index i of ChainNodes.
+func (c *CosmosChain) node(i int) (*ChainNode, error) {
+	if i < 0 || i >= len(c.ChainNodes) {
+		return nil, fmt.Errorf("node index %d out of range of %d nodes", i, len(c.ChainNodes))
+	}
+	return c.ChainNodes[i], nil
+}
+
+// StopNode stops the container of the node at index i of ChainNodes.
+// The node's state is kept, so the node can be started again with StartNode.
+func (c *CosmosChain) StopNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Stopping container", zap.String("container", n.Name()))
+	return n.StopContainer(ctx)
+}
+
+// StartNode starts the stopped container of the node at index i of ChainNodes,
+// reconnecting its RPC client to the node's new host ports, and waits for the node to catch up.
+func (c *CosmosChain) StartNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Starting container", zap.String("container", n.Name()))
+	return n.StartContainer(ctx)
+}
+
+// RestartNode stops and then starts the node at index i of ChainNodes.
+func (c *CosmosChain) RestartNode(ctx context.Context, i int) error {
+	if err := c.StopNode(ctx, i); err != nil {
+		return err
+	}
+	return c.StartNode(ctx, i)
+}
+
+// StopAllNodes stops the containers of every node, halting the chain.
+func (c *CosmosChain) StopAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for i := range c.ChainNodes {
+		i := i
+		eg.Go(func() error {
+			return c.StopNode(ctx, i)
+		})
+	}
+	return eg.Wait()
+}
+
+// StartAllNodes starts the stopped containers of every node, and waits for the nodes to catch up.
+func (c *CosmosChain) StartAllNodes(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := range c.ChainNodes {
+		i := i
+		eg.Go(func() error {
+			return c.StartNode(egCtx, i)
+		})
+	}
+	return eg.Wait()
+}
+
 // Acknowledgements implements ibc.Chain, returning all acknowledgments in block at height
 func (c *CosmosChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
 	var acks []*chanTypes.MsgAcknowledgement
@@ -0,0 +1,118 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	tmconfig "github.com/tendermint/tendermint/config"
+	"go.uber.org/zap"
+)
+
+// FullNodeOptions configures a full node added to a running chain by AddFullNode.
+type FullNodeOptions struct {
+	// StateSync bootstraps the node from the latest snapshot of the chain's state taken by the validators,
+	// rather than by replaying every block since genesis.
+	// The node has no history from before the snapshot.
+	// The chain must be configured with a non-zero ibc.ChainConfig.SnapshotInterval.
+	StateSync bool
+}
+
+// AddFullNode creates a full node from the chain's genesis, peers it with the chain's running nodes,
+// and starts it, waiting for it to catch up with the chain.
+// The node is appended to ChainNodes once its container is created, even if it then fails to start,
+// but the chain keeps sending its own queries and transactions to the nodes it started with.
+func (c *CosmosChain) AddFullNode(ctx context.Context, opts FullNodeOptions) (*ChainNode, error) {
+	if opts.StateSync && c.cfg.SnapshotInterval == 0 {
+		return nil, fmt.Errorf("state sync requires the validators of chain %s to take snapshots: set ChainConfig.SnapshotInterval", c.cfg.ChainID)
+	}
+
+	ref := c.ChainNodes[0]
+	n := &ChainNode{
+		log: c.log,
+
+		Home:         ref.Home,
+		Index:        c.nextNodeIndex(),
+		Chain:        c,
+		DockerClient: ref.DockerClient,
+		NetworkID:    ref.NetworkID,
+		TestName:     ref.TestName,
+		Image:        c.cfg.Images[0],
+	}
+	n.MkDir()
+
+	if err := n.InitFullNodeFiles(ctx); err != nil {
+		return nil, fmt.Errorf("init full node files: %w", err)
+	}
+	if _, err := dockerutil.CopyFile(ref.GenesisFilePath(), n.GenesisFilePath()); err != nil {
+		return nil, fmt.Errorf("copy genesis file: %w", err)
+	}
+
+	// The running nodes keep their persistent peers; the new node dials them.
+	cfg := tmconfig.DefaultConfig()
+	applyConfigChanges(cfg, append(c.ChainNodes, n).PeerString())
+	if opts.StateSync {
+		if err := c.configureStateSync(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	tmconfig.WriteConfigFile(n.TMConfigPath(), cfg)
+
+	if err := n.CreateNodeContainer(ctx); err != nil {
+		return nil, err
+	}
+	// Append the node as soon as its container exists, so that it is managed and cleaned up
+	// with the chain's other nodes even if it fails to start or catch up.
+	c.ChainNodes = append(c.ChainNodes, n)
+
+	c.log.Info("Starting added full node", zap.String("container", n.Name()), zap.Bool("state_sync", opts.StateSync))
+	if err := n.StartContainer(ctx); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// configureStateSync configures a node to bootstrap through state sync,
+// trusting the chain's latest block and verifying snapshots through the RPC of the chain's first nodes.
+func (c *CosmosChain) configureStateSync(ctx context.Context, cfg *tmconfig.Config) error {
+	src := c.getFullNode()
+	height, err := src.Height(ctx)
+	if err != nil {
+		return err
+	}
+	commit, err := src.Client.Commit(ctx, int64Ptr(height))
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get commit at height %d: %w", height, err)
+	}
+
+	// State sync requires two RPC servers, though they may be the same.
+	servers := make([]string, 2)
+	for i := range servers {
+		servers[i] = fmt.Sprintf("http://%s:26657", c.ChainNodes[i%len(c.ChainNodes)].HostName())
+	}
+
+	cfg.StateSync.Enable = true
+	cfg.StateSync.RPCServers = servers
+	cfg.StateSync.TrustHeight = int64(height)
+	cfg.StateSync.TrustHash = commit.Header.Hash().String()
+	return nil
+}
+
+var snapshotIntervalRE = regexp.MustCompile(`(?m)^snapshot-interval = \d+$`)
+
+// SetSnapshotInterval configures the node to take a snapshot of the chain's state every interval blocks,
+// for other nodes to bootstrap from through state sync.
+// The node's app.toml is left unchanged if the chain does not support snapshots.
+func (tn *ChainNode) SetSnapshotInterval(interval uint64) error {
+	path := filepath.Join(tn.Dir(), "config", "app.toml")
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	bz = snapshotIntervalRE.ReplaceAll(bz, []byte("snapshot-interval = "+strconv.FormatUint(interval, 10)))
+	return os.WriteFile(path, bz, 0644) //nolint
+}
@@ -0,0 +1,52 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_AddFullNode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	gaia, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0", SnapshotInterval: 10}})
+
+	nodeCount := len(gaia.ChainNodes)
+	rpcAddr := gaia.GetRPCAddress()
+
+	t.Run("replay from genesis", func(t *testing.T) {
+		n, err := gaia.AddFullNode(ctx, cosmos.FullNodeOptions{})
+		require.NoError(t, err)
+		require.Len(t, gaia.ChainNodes, nodeCount+1)
+		require.NoError(t, test.WaitForBlocks(ctx, 2, n))
+
+		// The chain keeps querying the node it started with.
+		require.Equal(t, rpcAddr, gaia.GetRPCAddress())
+
+		stat, err := n.Client.Status(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, stat.SyncInfo.EarliestBlockHeight)
+	})
+
+	t.Run("state sync", func(t *testing.T) {
+		// Let the validators take a snapshot.
+		require.NoError(t, test.WaitForBlocks(ctx, 12, gaia))
+
+		n, err := gaia.AddFullNode(ctx, cosmos.FullNodeOptions{StateSync: true})
+		require.NoError(t, err)
+		require.Len(t, gaia.ChainNodes, nodeCount+2)
+		require.NoError(t, test.WaitForBlocks(ctx, 2, n))
+
+		stat, err := n.Client.Status(ctx)
+		require.NoError(t, err)
+		require.Greater(t, stat.SyncInfo.EarliestBlockHeight, int64(1), "node replayed blocks since genesis")
+	})
+}
@@ -0,0 +1,84 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// ModifyGenesis returns a function, suitable for ibc.ChainConfig.ModifyGenesis,
+// that sets each of genesisKVs in the genesis file in order.
+func ModifyGenesis(genesisKVs []ibc.GenesisKV) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(_ ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		return modifyGenesisKVs(genbz, genesisKVs)
+	}
+}
+
+// modifyGenesisKVs sets each of genesisKVs in the JSON document genbz.
+func modifyGenesisKVs(genbz []byte, genesisKVs []ibc.GenesisKV) ([]byte, error) {
+	// Decode numbers as json.Number so that large integers, such as token supplies, keep their precision.
+	dec := json.NewDecoder(bytes.NewReader(genbz))
+	dec.UseNumber()
+	var g interface{}
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	for _, kv := range genesisKVs {
+		if kv.Key == "" {
+			return nil, fmt.Errorf("genesis modification has an empty key")
+		}
+		var err error
+		g, err = setPath(g, strings.Split(kv.Key, "."), kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set %s in genesis file: %w", kv.Key, err)
+		}
+	}
+
+	out, err := json.Marshal(g)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis file: %w", err)
+	}
+	return out, nil
+}
+
+// setPath sets value at path within the decoded JSON node, and returns the updated node.
+func setPath(node interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key := path[0]
+
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, err := setPath(n[key], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[key] = child
+		return n, nil
+	case []interface{}:
+		i, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("key %q indexes an array", key)
+		}
+		if i < 0 || i >= len(n) {
+			return nil, fmt.Errorf("index %d out of range of array of length %d", i, len(n))
+		}
+		child, err := setPath(n[i], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		n[i] = child
+		return n, nil
+	case nil:
+		// Create missing objects on the path.
+		return setPath(map[string]interface{}{}, path, value)
+	default:
+		return nil, fmt.Errorf("key %q indexes a %T, not an object or array", key, node)
+	}
+}
@@ -0,0 +1,82 @@
+package cosmos_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesis = `{
+  "chain_id": "test-1",
+  "app_state": {
+    "bank": {
+      "supply": [{"denom": "stake", "amount": "1"}]
+    },
+    "gov": {
+      "voting_params": {"voting_period": "172800s"}
+    },
+    "staking": {
+      "params": {"max_validators": 100}
+    },
+    "mint": {
+      "minter": {"inflation": 123456789012345678901234567890}
+    }
+  }
+}`
+
+func modifyGenesis(t *testing.T, kvs ...ibc.GenesisKV) (map[string]interface{}, error) {
+	t.Helper()
+
+	out, err := cosmos.ModifyGenesis(kvs)(ibc.ChainConfig{}, []byte(testGenesis))
+	if err != nil {
+		return nil, err
+	}
+
+	var g map[string]interface{}
+	require.NoError(t, json.Unmarshal(out, &g))
+	return g, nil
+}
+
+func TestModifyGenesis(t *testing.T) {
+	g, err := modifyGenesis(t,
+		ibc.GenesisKV{Key: "app_state.gov.voting_params.voting_period", Value: "10s"},
+		ibc.GenesisKV{Key: "app_state.staking.params.max_validators", Value: 4},
+		ibc.GenesisKV{Key: "app_state.bank.supply.0.amount", Value: "1000"},
+		ibc.GenesisKV{Key: "app_state.feemarket.params.enabled", Value: false},
+	)
+	require.NoError(t, err)
+
+	appState := g["app_state"].(map[string]interface{})
+	require.Equal(t, "10s", appState["gov"].(map[string]interface{})["voting_params"].(map[string]interface{})["voting_period"])
+	require.EqualValues(t, 4, appState["staking"].(map[string]interface{})["params"].(map[string]interface{})["max_validators"])
+	require.Equal(t, "1000", appState["bank"].(map[string]interface{})["supply"].([]interface{})[0].(map[string]interface{})["amount"])
+	require.Equal(t, false, appState["feemarket"].(map[string]interface{})["params"].(map[string]interface{})["enabled"])
+	require.Equal(t, "test-1", g["chain_id"])
+}
+
+func TestModifyGenesis_PreservesNumbers(t *testing.T) {
+	out, err := cosmos.ModifyGenesis(nil)(ibc.ChainConfig{}, []byte(testGenesis))
+	require.NoError(t, err)
+	require.Contains(t, string(out), `"inflation":123456789012345678901234567890`)
+}
+
+func TestModifyGenesis_Errors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		key  string
+	}{
+		{name: "empty key", key: ""},
+		{name: "index scalar", key: "chain_id.foo"},
+		{name: "index out of range", key: "app_state.bank.supply.1.amount"},
+		{name: "non-integer array index", key: "app_state.bank.supply.first"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := modifyGenesis(t, ibc.GenesisKV{Key: tc.key, Value: "x"})
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_Governance(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	gaia, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{
+		ChainID: "cosmoshub-0",
+		GenesisModifications: []ibc.GenesisKV{
+			{Key: "app_state.gov.voting_params.voting_period", Value: "10s"},
+			{Key: "app_state.gov.deposit_params.min_deposit.0.denom", Value: "uatom"},
+		},
+	}})
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "user", 100_000_000, gaia)[0]
+	deposit := "10000000" + gaia.Config().Denom
+
+	t.Run("text proposal passes", func(t *testing.T) {
+		// Submit with half the minimum deposit, so the proposal only enters its voting period after a second deposit.
+		proposalID, err := gaia.TextProposal(ctx, user.KeyName, cosmos.TextProposal{
+			Deposit:     "5000000" + gaia.Config().Denom,
+			Title:       "Text proposal",
+			Description: "A text proposal",
+		})
+		require.NoError(t, err)
+
+		prop, err := gaia.QueryProposal(ctx, proposalID)
+		require.NoError(t, err)
+		require.Equal(t, govtypes.StatusDepositPeriod, prop.Status)
+		require.Equal(t, "Text proposal", prop.GetTitle())
+
+		require.NoError(t, gaia.DepositOnProposal(ctx, user.KeyName, proposalID, "5000000"+gaia.Config().Denom))
+		require.NoError(t, gaia.VoteOnProposalAllValidators(ctx, proposalID, cosmos.ProposalVoteYes))
+		require.NoError(t, gaia.VoteOnProposal(ctx, user.KeyName, proposalID, cosmos.ProposalVoteNo))
+
+		height, err := gaia.Height(ctx)
+		require.NoError(t, err)
+		_, err = gaia.PollForProposalStatus(ctx, height+15, proposalID, govtypes.StatusPassed)
+		require.NoError(t, err)
+
+		tally, err := gaia.QueryProposalTally(ctx, proposalID)
+		require.NoError(t, err)
+		require.True(t, tally.Yes.IsPositive())
+		require.True(t, tally.No.IsPositive())
+		require.True(t, tally.NoWithVeto.IsZero())
+	})
+
+	t.Run("param change proposal is rejected", func(t *testing.T) {
+		proposalID, err := gaia.ParamChangeProposal(ctx, user.KeyName, cosmos.ParamChangeProposal{
+			Deposit:     deposit,
+			Title:       "Param change proposal",
+			Description: "Change the maximum number of validators",
+			Changes: []cosmos.ParamChange{
+				{Subspace: "staking", Key: "MaxValidators", Value: 10},
+			},
+		})
+		require.NoError(t, err)
+
+		// The first of the chain's two validators vetoes the proposal with half of the voting power.
+		require.NoError(t, gaia.VoteOnProposalValidator(ctx, 0, proposalID, cosmos.ProposalVoteNoWithVeto))
+
+		height, err := gaia.Height(ctx)
+		require.NoError(t, err)
+		_, err = gaia.PollForProposalStatus(ctx, height+15, proposalID, govtypes.StatusRejected)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,255 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/v3/modules/core/exported"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ ibc.IBCQueryingChain = (*CosmosChain)(nil)
+
+// dialGRPC connects to the gRPC server at the chain's host gRPC address.
+// The caller must close the connection.
+func (c *CosmosChain) dialGRPC() (*grpc.ClientConn, error) {
+	return grpc.Dial(c.GetHostGRPCAddress(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+}
+
+// QueryClientStates implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryClientStates(ctx context.Context) (clienttypes.IdentifiedClientStates, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var states clienttypes.IdentifiedClientStates
+	page := &query.PageRequest{}
+	for {
+		res, err := clienttypes.NewQueryClient(conn).ClientStates(ctx, &clienttypes.QueryClientStatesRequest{Pagination: page})
+		if err != nil {
+			return nil, fmt.Errorf("query client states: %w", err)
+		}
+		states = append(states, res.ClientStates...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+	for _, state := range states {
+		if err := state.UnpackInterfaces(defaultEncoding.InterfaceRegistry); err != nil {
+			return nil, fmt.Errorf("unpack client state %s: %w", state.ClientId, err)
+		}
+	}
+	return states, nil
+}
+
+// QueryClientState implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryClientState(ctx context.Context, clientID string) (exported.ClientState, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := clienttypes.NewQueryClient(conn).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("query client state %s: %w", clientID, err)
+	}
+	var state exported.ClientState
+	if err := defaultEncoding.InterfaceRegistry.UnpackAny(res.ClientState, &state); err != nil {
+		return nil, fmt.Errorf("unpack client state %s: %w", clientID, err)
+	}
+	return state, nil
+}
+
+// QueryConsensusState implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (exported.ConsensusState, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := clienttypes.NewQueryClient(conn).ConsensusState(ctx, &clienttypes.QueryConsensusStateRequest{
+		ClientId:       clientID,
+		RevisionNumber: height.RevisionNumber,
+		RevisionHeight: height.RevisionHeight,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query consensus state of client %s at height %s: %w", clientID, height, err)
+	}
+	var state exported.ConsensusState
+	if err := defaultEncoding.InterfaceRegistry.UnpackAny(res.ConsensusState, &state); err != nil {
+		return nil, fmt.Errorf("unpack consensus state of client %s at height %s: %w", clientID, height, err)
+	}
+	return state, nil
+}
+
+// QueryConnections implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryConnections(ctx context.Context) ([]*conntypes.IdentifiedConnection, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var connections []*conntypes.IdentifiedConnection
+	page := &query.PageRequest{}
+	for {
+		res, err := conntypes.NewQueryClient(conn).Connections(ctx, &conntypes.QueryConnectionsRequest{Pagination: page})
+		if err != nil {
+			return nil, fmt.Errorf("query connections: %w", err)
+		}
+		connections = append(connections, res.Connections...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return connections, nil
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+}
+
+// QueryConnection implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryConnection(ctx context.Context, connectionID string) (*conntypes.ConnectionEnd, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := conntypes.NewQueryClient(conn).Connection(ctx, &conntypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return nil, fmt.Errorf("query connection %s: %w", connectionID, err)
+	}
+	return res.Connection, nil
+}
+
+// QueryChannels implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryChannels(ctx context.Context) ([]*chantypes.IdentifiedChannel, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var channels []*chantypes.IdentifiedChannel
+	page := &query.PageRequest{}
+	for {
+		res, err := chantypes.NewQueryClient(conn).Channels(ctx, &chantypes.QueryChannelsRequest{Pagination: page})
+		if err != nil {
+			return nil, fmt.Errorf("query channels: %w", err)
+		}
+		channels = append(channels, res.Channels...)
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return channels, nil
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+}
+
+// QueryChannel implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, fmt.Errorf("query channel %s/%s: %w", portID, channelID, err)
+	}
+	return res.Channel, nil
+}
+
+// QueryPacketCommitments implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var sequences []uint64
+	page := &query.PageRequest{}
+	for {
+		res, err := chantypes.NewQueryClient(conn).PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: page,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("query packet commitments on channel %s/%s: %w", portID, channelID, err)
+		}
+		for _, commitment := range res.Commitments {
+			sequences = append(sequences, commitment.Sequence)
+		}
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			return sequences, nil
+		}
+		page = &query.PageRequest{Key: res.Pagination.NextKey}
+	}
+}
+
+// QueryPacketReceipt implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).PacketReceipt(ctx, &chantypes.QueryPacketReceiptRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		return false, fmt.Errorf("query receipt of packet %d on channel %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return res.Received, nil
+}
+
+// QueryPacketAcknowledgement implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).PacketAcknowledgement(ctx, &chantypes.QueryPacketAcknowledgementRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query acknowledgement of packet %d on channel %s/%s: %w", sequence, portID, channelID, err)
+	}
+	return res.Acknowledgement, nil
+}
+
+// QueryNextSequenceReceive implements ibc.IBCQueryingChain.
+func (c *CosmosChain) QueryNextSequenceReceive(ctx context.Context, portID, channelID string) (uint64, error) {
+	conn, err := c.dialGRPC()
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	res, err := chantypes.NewQueryClient(conn).NextSequenceReceive(ctx, &chantypes.QueryNextSequenceReceiveRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("query next sequence receive on channel %s/%s: %w", portID, channelID, err)
+	}
+	return res.NextSequenceReceive, nil
+}
@@ -0,0 +1,131 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosChain_IBCQueries(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	gaia0, gaia1 := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	r := ibctest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network, home,
+	)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	eRep := testreporter.NewNopReporter().RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	clients, err := gaia0.QueryClientStates(ctx)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	clientState, err := gaia0.QueryClientState(ctx, clients[0].ClientId)
+	require.NoError(t, err)
+	_, err = gaia0.QueryConsensusState(ctx, clients[0].ClientId, clientState.GetLatestHeight().(clienttypes.Height))
+	require.NoError(t, err)
+
+	connections, err := gaia0.QueryConnections(ctx)
+	require.NoError(t, err)
+	require.Len(t, connections, 1)
+	require.Equal(t, clients[0].ClientId, connections[0].ClientId)
+	connection, err := gaia0.QueryConnection(ctx, connections[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, connections[0].Counterparty, connection.Counterparty)
+
+	channels, err := gaia0.QueryChannels(ctx)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	src := channels[0]
+	require.Equal(t, chantypes.OPEN, src.State)
+	require.Equal(t, []string{connections[0].Id}, src.ConnectionHops)
+	dst, err := gaia1.QueryChannel(ctx, src.Counterparty.PortId, src.Counterparty.ChannelId)
+	require.NoError(t, err)
+	require.Equal(t, src.ChannelId, dst.Counterparty.ChannelId)
+
+	users := ibctest.GetAndFundTestUsers(t, ctx, "user", 10_000_000, gaia0, gaia1)
+	tx, err := gaia0.SendIBCTransfer(ctx, src.ChannelId, users[0].KeyName, ibc.WalletAmount{
+		Address: users[1].Bech32Address(gaia1.Config().Bech32Prefix),
+		Denom:   gaia0.Config().Denom,
+		Amount:  1000,
+	}, nil)
+	require.NoError(t, err)
+	seq := tx.Packet.Sequence
+
+	// The packet is pending until the relayer relays it.
+	commitments, err := gaia0.QueryPacketCommitments(ctx, src.PortId, src.ChannelId)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{seq}, commitments)
+	received, err := gaia1.QueryPacketReceipt(ctx, src.Counterparty.PortId, src.Counterparty.ChannelId, seq)
+	require.NoError(t, err)
+	require.False(t, received)
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+	_, err = test.PollForAck(ctx, gaia0, tx.Height, tx.Height+30, tx.Packet)
+	require.NoError(t, err)
+
+	commitments, err = gaia0.QueryPacketCommitments(ctx, src.PortId, src.ChannelId)
+	require.NoError(t, err)
+	require.Empty(t, commitments)
+	received, err = gaia1.QueryPacketReceipt(ctx, src.Counterparty.PortId, src.Counterparty.ChannelId, seq)
+	require.NoError(t, err)
+	require.True(t, received)
+	ack, err := gaia1.QueryPacketAcknowledgement(ctx, src.Counterparty.PortId, src.Counterparty.ChannelId, seq)
+	require.NoError(t, err)
+	require.NotEmpty(t, ack)
+
+	// The next sequence to receive only advances on ordered channels.
+	next, err := gaia1.QueryNextSequenceReceive(ctx, src.Counterparty.PortId, src.Counterparty.ChannelId)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, next)
+}
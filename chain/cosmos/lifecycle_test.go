@@ -0,0 +1,42 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_NodeLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	gaia, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}})
+
+	t.Run("restart the node serving RPC", func(t *testing.T) {
+		// The chain's RPC is served by its first full node, after its two validators.
+		require.NoError(t, gaia.RestartNode(ctx, 2))
+		require.NoError(t, test.WaitForBlocks(ctx, 2, gaia))
+	})
+
+	t.Run("halt and resume the chain", func(t *testing.T) {
+		require.NoError(t, gaia.StopAllNodes(ctx))
+
+		heightCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		_, err := gaia.Height(heightCtx)
+		require.Error(t, err, "stopped chain reported a height")
+
+		require.NoError(t, gaia.StartAllNodes(ctx))
+		require.NoError(t, test.WaitForBlocks(ctx, 2, gaia))
+	})
+
+	require.Error(t, gaia.StopNode(ctx, len(gaia.ChainNodes)))
+}
@@ -0,0 +1,273 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	ibctm "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"github.com/strangelove-ventures/ibctest/test"
+	tmconfig "github.com/tendermint/tendermint/config"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/zap"
+)
+
+// A chain is forked by copying the home directory of a validator, including its priv_validator_key.json,
+// to a new node on a Docker network of its own.
+// The copy signs blocks that the chain never sees, while the validator keeps signing the chain's blocks at the same heights.
+// The copy can only produce blocks alone if the validator holds more than two thirds of the voting power,
+// so the validator first delegates more tokens to itself if necessary.
+
+// forkBlocksMax is how many blocks ConflictingHeaders waits for the fork to diverge from the chain.
+const forkBlocksMax = 30
+
+var (
+	_ ibc.DoubleSigningChain  = (*CosmosChain)(nil)
+	_ ibc.ClientUpdatingChain = (*CosmosChain)(nil)
+)
+
+// ConflictingHeaders implements ibc.DoubleSigningChain.
+func (c *CosmosChain) ConflictingHeaders(ctx context.Context, trustedHeight clienttypes.Height) (*ibctm.Misbehaviour, error) {
+	if c.doubleSigner == nil {
+		if err := c.startDoubleSigner(ctx); err != nil {
+			return nil, fmt.Errorf("start double signer: %w", err)
+		}
+	}
+	fork, chain := c.doubleSigner, c.getFullNode()
+
+	for i := 0; i < forkBlocksMax; i++ {
+		forkHeight, err := fork.Height(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("fork height: %w", err)
+		}
+		chainHeight, err := chain.Height(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("chain height: %w", err)
+		}
+
+		h := forkHeight
+		if chainHeight < h {
+			h = chainHeight
+		}
+		if h > trustedHeight.RevisionHeight {
+			forkCommit, err := fork.Client.Commit(ctx, int64Ptr(h))
+			if err != nil {
+				return nil, fmt.Errorf("fork commit at height %d: %w", h, err)
+			}
+			chainCommit, err := chain.Client.Commit(ctx, int64Ptr(h))
+			if err != nil {
+				return nil, fmt.Errorf("chain commit at height %d: %w", h, err)
+			}
+			if !bytes.Equal(forkCommit.Header.Hash(), chainCommit.Header.Hash()) {
+				return c.misbehaviour(ctx, trustedHeight, h)
+			}
+		}
+
+		if err := test.WaitForBlocks(ctx, 1, fork, chain); err != nil {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("fork of chain %s did not diverge above height %d within %d blocks", c.cfg.ChainID, trustedHeight.RevisionHeight, forkBlocksMax)
+}
+
+// Misbehaviours implements ibc.ClientUpdatingChain.
+func (c *CosmosChain) Misbehaviours(ctx context.Context, height uint64) ([]ibc.ClientMisbehaviour, error) {
+	var found []ibc.ClientMisbehaviour
+	err := rangeBlockMessages(ctx, c.getFullNode().Client, height, func(msg types.Msg) bool {
+		if m, ok := msg.(*clienttypes.MsgSubmitMisbehaviour); ok {
+			found = append(found, ibc.ClientMisbehaviour{ClientID: m.ClientId, Signer: m.Signer})
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find misbehaviour at height %d: %w", height, err)
+	}
+	return found, nil
+}
+
+// misbehaviour returns the conflicting headers of the fork and the chain at height.
+func (c *CosmosChain) misbehaviour(ctx context.Context, trustedHeight clienttypes.Height, height uint64) (*ibctm.Misbehaviour, error) {
+	chainClient := c.getFullNode().Client
+
+	// A light client stores the hash of the next validator set with each consensus state,
+	// so the validators trusted at trustedHeight are those of the following block.
+	trustedVals, err := validatorSet(ctx, chainClient, trustedHeight.RevisionHeight+1)
+	if err != nil {
+		return nil, err
+	}
+	trustedValSet, err := tmtypes.NewValidatorSet(trustedVals).ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	forkHeader, err := lightHeader(ctx, c.doubleSigner.Client, height, trustedHeight, trustedValSet)
+	if err != nil {
+		return nil, fmt.Errorf("fork header: %w", err)
+	}
+	chainHeader, err := lightHeader(ctx, chainClient, height, trustedHeight, trustedValSet)
+	if err != nil {
+		return nil, fmt.Errorf("chain header: %w", err)
+	}
+	return &ibctm.Misbehaviour{
+		Header1: forkHeader,
+		Header2: chainHeader,
+	}, nil
+}
+
+// lightHeader returns the header at height from the node at client, for a light client to verify against trustedVals.
+func lightHeader(ctx context.Context, client rpcclient.Client, height uint64, trustedHeight clienttypes.Height, trustedVals *tmproto.ValidatorSet) (*ibctm.Header, error) {
+	commit, err := client.Commit(ctx, int64Ptr(height))
+	if err != nil {
+		return nil, fmt.Errorf("tendermint rpc get commit at height %d: %w", height, err)
+	}
+	vals, err := validatorSet(ctx, client, height)
+	if err != nil {
+		return nil, err
+	}
+	valSet, err := tmtypes.NewValidatorSet(vals).ToProto()
+	if err != nil {
+		return nil, err
+	}
+	return &ibctm.Header{
+		SignedHeader:      commit.SignedHeader.ToProto(),
+		ValidatorSet:      valSet,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedVals,
+	}, nil
+}
+
+// startDoubleSigner starts a copy of the first validator on an isolated network.
+func (c *CosmosChain) startDoubleSigner(ctx context.Context) error {
+	v := c.validators()[0]
+	if err := c.ensureSupermajority(ctx, v); err != nil {
+		return err
+	}
+
+	networkID, err := dockerutil.CreateNetwork(ctx, v.DockerClient, v.TestName)
+	if err != nil {
+		return fmt.Errorf("create isolated network: %w", err)
+	}
+
+	fork := &ChainNode{
+		log: c.log,
+
+		Home:         v.Home,
+		Index:        c.nextNodeIndex(),
+		Chain:        c,
+		DockerClient: v.DockerClient,
+		NetworkID:    networkID,
+		TestName:     v.TestName,
+		Image:        v.Image,
+	}
+	fork.MkDir()
+
+	// Stop the validator while its home directory is copied, so that the copy of its state is consistent.
+	if err := v.StopContainer(ctx); err != nil {
+		return fmt.Errorf("stop validator %s: %w", v.Name(), err)
+	}
+	copyErr := copyDir(v.Dir(), fork.Dir())
+	if err := v.StartContainer(ctx); err != nil {
+		return fmt.Errorf("restart validator %s: %w", v.Name(), err)
+	}
+	if copyErr != nil {
+		return fmt.Errorf("copy validator home directory: %w", copyErr)
+	}
+
+	// Without peers, the fork must not wait to catch up through fast sync before producing blocks.
+	cfg := tmconfig.DefaultConfig()
+	applyConfigChanges(cfg, "")
+	cfg.FastSyncMode = false
+	tmconfig.WriteConfigFile(fork.TMConfigPath(), cfg)
+
+	if err := fork.CreateNodeContainer(ctx); err != nil {
+		return err
+	}
+	if err := fork.StartContainer(ctx); err != nil {
+		return err
+	}
+	c.log.Info("Started double signer", zap.String("container", fork.Name()), zap.String("validator", v.Name()))
+
+	c.doubleSigner = fork
+	return nil
+}
+
+// ensureSupermajority delegates to validator v, from its own key, until it holds more than two thirds of the voting power.
+func (c *CosmosChain) ensureSupermajority(ctx context.Context, v *ChainNode) error {
+	keybz, err := os.ReadFile(v.PrivValKeyFilePath())
+	if err != nil {
+		return err
+	}
+	var key PrivValidatorKeyFile
+	if err := json.Unmarshal(keybz, &key); err != nil {
+		return fmt.Errorf("decode %s: %w", v.PrivValKeyFilePath(), err)
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return err
+	}
+	vals, err := c.ValidatorSet(ctx, height)
+	if err != nil {
+		return err
+	}
+	var power, total int64
+	for _, val := range vals {
+		total += val.VotingPower
+		if strings.EqualFold(val.Address.String(), key.Address) {
+			power = val.VotingPower
+		}
+	}
+	if 3*power > 2*total {
+		return nil
+	}
+
+	operator, err := v.OperatorAddress()
+	if err != nil {
+		return err
+	}
+	extra := types.NewCoin(BondDenom, types.TokensFromConsensusPower(2*total-3*power+1, types.DefaultPowerReduction))
+	if err := v.Delegate(ctx, valKey, operator, extra.String()); err != nil {
+		return fmt.Errorf("delegate %s to validator %s: %w", extra, v.Name(), err)
+	}
+
+	// Voting power changes take effect two blocks after the delegation is committed.
+	return test.WaitForBlocks(ctx, 2, c)
+}
+
+// copyDir copies the regular files in the directory tree src to dst.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, 0755)
+		case info.Mode().IsRegular():
+			_, err := dockerutil.CopyFile(path, target)
+			return err
+		default:
+			return nil
+		}
+	})
+}
+
+func int64Ptr(v uint64) *int64 {
+	i := int64(v)
+	return &i
+}
@@ -0,0 +1,197 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// Nodes are cut off from each other by disconnecting their containers from the chain's Docker network.
+// Tendermint resolves the hostnames of a node's persistent peers only when the node starts,
+// and a container that is connected to a network again may be given a different IP address.
+// A node that is connected again dials its peers at their unchanged addresses, which restores its connectivity;
+// but the validators of an isolated group are all given new addresses on the group's network,
+// so they are restarted to resolve each other, and restarted again to resolve every node once the partition heals.
+
+// partitionGroup is a group of validators isolated on a network of its own.
+type partitionGroup struct {
+	networkID string
+	nodes     ChainNodes
+}
+
+// DisconnectNode disconnects the node at index i of ChainNodes from the chain's network.
+// The node can neither reach its peers nor be reached by relayers or other clients on the network,
+// until ReconnectNode or HealNetwork is called.
+func (c *CosmosChain) DisconnectNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	if c.disconnectedNodes[n] {
+		return fmt.Errorf("node %d is already disconnected", i)
+	}
+	c.log.Info("Disconnecting node from network", zap.String("container", n.Name()))
+	if err := n.DisconnectNetwork(ctx, n.NetworkID); err != nil {
+		return fmt.Errorf("disconnect node %d: %w", i, err)
+	}
+	c.markDisconnected(n)
+	return nil
+}
+
+// markDisconnected records that n is disconnected from the chain's network,
+// so that ReconnectNode and HealNetwork connect it again.
+func (c *CosmosChain) markDisconnected(n *ChainNode) {
+	if c.disconnectedNodes == nil {
+		c.disconnectedNodes = make(map[*ChainNode]bool)
+	}
+	c.disconnectedNodes[n] = true
+}
+
+// ReconnectNode connects the node at index i of ChainNodes, which was disconnected by DisconnectNode,
+// to the chain's network again.
+func (c *CosmosChain) ReconnectNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	if !c.disconnectedNodes[n] {
+		return fmt.Errorf("node %d is not disconnected", i)
+	}
+	c.log.Info("Reconnecting node to network", zap.String("container", n.Name()))
+	if err := n.ConnectNetwork(ctx, n.NetworkID); err != nil {
+		return fmt.Errorf("reconnect node %d: %w", i, err)
+	}
+	delete(c.disconnectedNodes, n)
+	return nil
+}
+
+// PartitionValidators splits the chain's validators into isolated groups,
+// each given as indexes of validators in ChainNodes.
+// The first group stays on the chain's network, along with every node that is not in a group,
+// such as the full nodes serving RPC; each other group is moved to a network of its own.
+// The validators of a group stay connected to each other.
+//
+// A group of validators holding no more than two thirds of the voting power cannot produce blocks,
+// so a partition in which no group holds a supermajority halts the chain until HealNetwork is called.
+func (c *CosmosChain) PartitionValidators(ctx context.Context, groups ...[]int) error {
+	if len(c.partitions) > 0 {
+		return fmt.Errorf("chain %s is already partitioned", c.cfg.ChainID)
+	}
+	if len(groups) < 2 {
+		return fmt.Errorf("partition needs at least 2 groups, got %d", len(groups))
+	}
+
+	seen := make(map[int]bool)
+	nodeGroups := make([]ChainNodes, len(groups))
+	for g, group := range groups {
+		if len(group) == 0 {
+			return fmt.Errorf("partition group %d is empty", g)
+		}
+		for _, i := range group {
+			n, err := c.node(i)
+			if err != nil {
+				return err
+			}
+			if !n.Validator {
+				return fmt.Errorf("node %d is not a validator", i)
+			}
+			if seen[i] {
+				return fmt.Errorf("node %d is in more than one partition group", i)
+			}
+			seen[i] = true
+			nodeGroups[g] = append(nodeGroups[g], n)
+		}
+	}
+
+	for _, nodes := range nodeGroups[1:] {
+		if err := c.isolate(ctx, nodes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isolate moves nodes from the chain's network to a new network of their own.
+func (c *CosmosChain) isolate(ctx context.Context, nodes ChainNodes) error {
+	networkID, err := dockerutil.CreateNetwork(ctx, nodes[0].DockerClient, c.testName)
+	if err != nil {
+		return fmt.Errorf("create partition network: %w", err)
+	}
+
+	// Track the group as each node moves, so that HealNetwork can undo a partition that failed partway.
+	p := &partitionGroup{networkID: networkID}
+	c.partitions = append(c.partitions, p)
+
+	for _, n := range nodes {
+		c.log.Info("Isolating node", zap.String("container", n.Name()), zap.String("network", networkID))
+		if err := n.DisconnectNetwork(ctx, n.NetworkID); err != nil {
+			return fmt.Errorf("disconnect %s: %w", n.Name(), err)
+		}
+		if err := n.ConnectNetwork(ctx, networkID); err != nil {
+			// The node is on no network now, so HealNetwork must connect it to the chain's network again.
+			c.markDisconnected(n)
+			return fmt.Errorf("connect %s to partition network: %w", n.Name(), err)
+		}
+		p.nodes = append(p.nodes, n)
+	}
+
+	if len(nodes) == 1 {
+		return nil
+	}
+	return restartWithPeers(ctx, nodes, nodes.PeerString())
+}
+
+// HealNetwork connects every node disconnected by DisconnectNode to the chain's network again,
+// and moves the groups of validators isolated by PartitionValidators back onto the chain's network.
+func (c *CosmosChain) HealNetwork(ctx context.Context) error {
+	for n := range c.disconnectedNodes {
+		c.log.Info("Reconnecting node to network", zap.String("container", n.Name()))
+		if err := n.ConnectNetwork(ctx, n.NetworkID); err != nil {
+			return fmt.Errorf("reconnect %s: %w", n.Name(), err)
+		}
+		delete(c.disconnectedNodes, n)
+	}
+
+	peers := c.ChainNodes.PeerString()
+	for len(c.partitions) > 0 {
+		p := c.partitions[0]
+		for _, n := range p.nodes {
+			c.log.Info("Rejoining node to network", zap.String("container", n.Name()))
+			if err := n.DisconnectNetwork(ctx, p.networkID); err != nil {
+				return fmt.Errorf("disconnect %s from partition network: %w", n.Name(), err)
+			}
+			if err := n.ConnectNetwork(ctx, n.NetworkID); err != nil {
+				return fmt.Errorf("reconnect %s: %w", n.Name(), err)
+			}
+		}
+		if len(p.nodes) > 1 {
+			if err := restartWithPeers(ctx, p.nodes, peers); err != nil {
+				return err
+			}
+		}
+		if err := c.getFullNode().DockerClient.NetworkRemove(ctx, p.networkID); err != nil {
+			return fmt.Errorf("remove partition network: %w", err)
+		}
+		c.partitions = c.partitions[1:]
+	}
+	return nil
+}
+
+// restartWithPeers restarts nodes with peers as their persistent peers.
+func restartWithPeers(ctx context.Context, nodes ChainNodes, peers string) error {
+	var eg errgroup.Group
+	for _, n := range nodes {
+		n := n
+		eg.Go(func() error {
+			if err := n.StopContainer(ctx); err != nil {
+				return fmt.Errorf("stop %s: %w", n.Name(), err)
+			}
+			n.SetValidatorConfigAndPeers(peers)
+			return n.StartContainer(ctx)
+		})
+	}
+	return eg.Wait()
+}
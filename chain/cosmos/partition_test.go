@@ -0,0 +1,57 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_NetworkPartition(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 4, 1
+	gaia, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}, NumValidators: &nv, NumFullNodes: &nf})
+
+	// requireHalted asserts that the chain produces no blocks for a while.
+	requireHalted := func(t *testing.T) {
+		t.Helper()
+
+		// Allow a block that was already being committed to complete.
+		_ = test.WaitForBlocks(ctx, 1, gaia)
+
+		waitCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+		defer cancel()
+		require.ErrorIs(t, test.WaitForBlocks(waitCtx, 2, gaia), context.DeadlineExceeded, "partitioned chain produced blocks")
+	}
+
+	t.Run("disconnect one validator", func(t *testing.T) {
+		// The three other validators hold three quarters of the voting power, so the chain keeps producing blocks.
+		require.NoError(t, gaia.DisconnectNode(ctx, 0))
+		require.NoError(t, test.WaitForBlocks(ctx, 2, gaia))
+
+		require.NoError(t, gaia.ReconnectNode(ctx, 0))
+		require.NoError(t, test.WaitForBlocks(ctx, 2, gaia))
+	})
+
+	t.Run("split validators in half", func(t *testing.T) {
+		require.NoError(t, gaia.PartitionValidators(ctx, []int{0, 1}, []int{2, 3}))
+		requireHalted(t)
+
+		healed := test.HealAfter(ctx, 5*time.Second, gaia.HealNetwork)
+		require.NoError(t, <-healed)
+		require.NoError(t, test.WaitForBlocks(ctx, 2, gaia))
+	})
+
+	t.Run("partition rejects full nodes", func(t *testing.T) {
+		require.Error(t, gaia.PartitionValidators(ctx, []int{0, 1, 2}, []int{3, 4}))
+	})
+}
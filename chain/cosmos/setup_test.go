@@ -0,0 +1,42 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// buildSingleCosmosChain builds and starts an interchain of the single builtin chain described by spec,
+// which is closed when the test finishes.
+func buildSingleCosmosChain(t *testing.T, spec *ibctest.ChainSpec) (*cosmos.CosmosChain, context.Context) {
+	t.Helper()
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{spec})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := ibctest.NewInterchain().AddChain(chain)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, testreporter.NewNopReporter().RelayerExecReporter(t), ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	return chain, ctx
+}
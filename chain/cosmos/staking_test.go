@@ -0,0 +1,68 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosmosChain_Staking(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	nv, nf := 2, 1
+	gaia, ctx := buildSingleCosmosChain(t, &ibctest.ChainSpec{Name: "gaia", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}, NumValidators: &nv, NumFullNodes: &nf})
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "user", 100_000_000, gaia)[0]
+
+	// Give the user as many bond tokens as each validator self-delegated at genesis.
+	const stake = 100_000_000_000
+	require.NoError(t, gaia.ChainNodes[0].SendFunds(ctx, "validator", ibc.WalletAmount{
+		Address: user.Bech32Address(gaia.Config().Bech32Prefix),
+		Denom:   cosmos.BondDenom,
+		Amount:  stake,
+	}))
+
+	val0, err := gaia.ChainNodes[0].OperatorAddress()
+	require.NoError(t, err)
+	val1, err := gaia.ChainNodes[1].OperatorAddress()
+	require.NoError(t, err)
+
+	// votingPowers returns the voting power of each validator in the validator set at the current height.
+	votingPowers := func() []int64 {
+		t.Helper()
+
+		require.NoError(t, test.WaitForBlocks(ctx, 1, gaia))
+		height, err := gaia.Height(ctx)
+		require.NoError(t, err)
+		vals, err := gaia.ValidatorSet(ctx, height)
+		require.NoError(t, err)
+
+		powers := make([]int64, len(vals))
+		for i, v := range vals {
+			powers[i] = v.VotingPower
+		}
+		return powers
+	}
+
+	require.Equal(t, []int64{100_000, 100_000}, votingPowers())
+
+	require.NoError(t, gaia.Delegate(ctx, user.KeyName, val0, "100000000000stake"))
+	require.ElementsMatch(t, []int64{200_000, 100_000}, votingPowers())
+
+	require.NoError(t, gaia.Redelegate(ctx, user.KeyName, val0, val1, "50000000000stake"))
+	require.ElementsMatch(t, []int64{150_000, 150_000}, votingPowers())
+
+	require.NoError(t, gaia.Unbond(ctx, user.KeyName, val1, "50000000000stake"))
+	require.ElementsMatch(t, []int64{150_000, 100_000}, votingPowers())
+
+	require.NoError(t, gaia.CreateValidator(ctx, gaia.ChainNodes[2], 300_000_000_000))
+	require.ElementsMatch(t, []int64{300_000, 150_000, 100_000}, votingPowers())
+}
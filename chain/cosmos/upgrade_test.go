@@ -0,0 +1,121 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/cosmos"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestCosmosChain_Upgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	// Shorten the voting period so that the upgrade proposal passes before the upgrade height.
+	govGenesis := []ibc.GenesisKV{
+		{Key: "app_state.gov.voting_params.voting_period", Value: "10s"},
+		{Key: "app_state.gov.deposit_params.min_deposit.0.denom", Value: "uatom"},
+	}
+
+	cf := ibctest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*ibctest.ChainSpec{
+		{Name: "gaia", ChainName: "g1", Version: "v6.0.4", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0", GenesisModifications: govGenesis}},
+		{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	gaia0, gaia1 := chains[0], chains[1]
+
+	r := ibctest.NewBuiltinRelayerFactory(ibc.CosmosRly, zaptest.NewLogger(t)).Build(
+		t, client, network, home,
+	)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(gaia0).
+		AddChain(gaia1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  gaia0,
+			Chain2:  gaia1,
+			Relayer: r,
+			Path:    pathName,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	users := ibctest.GetAndFundTestUsers(t, ctx, "user", 10_000_000_000, gaia0, gaia1)
+	gaia0User, gaia1User := users[0], users[1]
+
+	channels, err := r.GetChannels(ctx, eRep, gaia0.Config().ChainID)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	transfer := func() {
+		t.Helper()
+
+		tx, err := gaia0.SendIBCTransfer(ctx, channels[0].ChannelID, gaia0User.KeyName, ibc.WalletAmount{
+			Address: gaia1User.Bech32Address(gaia1.Config().Bech32Prefix),
+			Denom:   gaia0.Config().Denom,
+			Amount:  1000,
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = test.PollForAck(ctx, gaia0, tx.Height, tx.Height+20, tx.Packet)
+		require.NoError(t, err)
+	}
+
+	transfer()
+
+	height, err := gaia0.Height(ctx)
+	require.NoError(t, err)
+
+	haltHeight := height + 20
+	require.NoError(t, gaia0.(*cosmos.CosmosChain).Upgrade(ctx, gaia0User.KeyName, cosmos.SoftwareUpgradeProposal{
+		Deposit:     "10000000" + gaia0.Config().Denom,
+		Title:       "Upgrade to v7",
+		Name:        "v7-Theta",
+		Description: "Upgrade gaia to v7",
+		Height:      haltHeight,
+	}, ibc.DockerImage{
+		Repository: gaia0.Config().Images[0].Repository,
+		Version:    "v7.0.1",
+	}))
+
+	height, err = gaia0.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, height, haltHeight)
+
+	// The client, connection and channel survive the upgrade.
+	transfer()
+}
@@ -39,6 +39,9 @@ type TendermintNode struct {
 	Image        ibc.DockerImage
 
 	containerID string
+
+	// Set during StartContainer.
+	hostRPCPort string
 }
 
 // TendermintNodes is a collection of TendermintNode
@@ -240,6 +243,7 @@ func (tn *TendermintNode) StartContainer(ctx context.Context) error {
 
 	port := dockerutil.GetHostPort(c, rpcPort)
 	fmt.Printf("{%s} RPC => %s\n", tn.Name(), port)
+	tn.hostRPCPort = port
 
 	err = tn.NewClient(fmt.Sprintf("tcp://%s", port))
 	if err != nil {
@@ -262,6 +266,12 @@ func (tn *TendermintNode) StartContainer(ctx context.Context) error {
 	}, retry.Context(ctx), retry.DelayType(retry.BackOffDelay))
 }
 
+// HostRPCPort returns the host address of the node's RPC port.
+// It is empty until StartContainer returns.
+func (tn *TendermintNode) HostRPCPort() string {
+	return tn.hostRPCPort
+}
+
 // InitValidatorFiles creates the node files and signs a genesis transaction
 func (tn *TendermintNode) InitValidatorFiles(ctx context.Context) error {
 	return tn.InitHomeFolder(ctx, "validator")
@@ -0,0 +1,362 @@
+package mock
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+const (
+	// BlockTime is the time between consecutive blocks of a mock chain.
+	BlockTime = time.Second
+
+	// GasSpent is the gas reported for every transaction.
+	// Mock chains do not charge fees for gas.
+	GasSpent = 100_000
+)
+
+// GenesisTime is the time of the first block of every mock chain.
+var GenesisTime = time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrNotSupported is returned by the methods of ibc.Chain that a mock chain does not implement.
+var ErrNotSupported = errors.New("not supported by mock chain")
+
+// Chain is an in-memory ibc.Chain.
+type Chain struct {
+	cfg ibc.ChainConfig
+
+	mu sync.Mutex
+
+	// Zero until the chain is started.
+	height  uint64
+	txCount int
+
+	kr keyring.Keyring
+
+	// Keyed by bech32 address, then by denom.
+	balances map[string]map[string]int64
+
+	// Traces of received IBC vouchers, keyed by IBC denom.
+	denomTraces map[string]transfertypes.DenomTrace
+
+	clients     []*clientState
+	connections []*connection
+	channels    []*channel
+
+	// Keyed by height.
+	acks     map[uint64][]ibc.PacketAcknowledgement
+	timeouts map[uint64][]ibc.PacketTimeout
+
+	// Called after every empty block, keyed by the ID returned from subscribe.
+	observers      map[int]func()
+	nextObserverID int
+}
+
+var _ ibc.Chain = (*Chain)(nil)
+
+// NewChain returns a mock chain that is not yet started.
+// The ChainID, Bech32Prefix and Denom of cfg must be set.
+// If they are empty, the Name of cfg defaults to its ChainID and its Type to "mock".
+func NewChain(cfg ibc.ChainConfig) *Chain {
+	if cfg.Name == "" {
+		cfg.Name = cfg.ChainID
+	}
+	if cfg.Type == "" {
+		cfg.Type = "mock"
+	}
+
+	return &Chain{
+		cfg: cfg,
+
+		kr: keyring.NewInMemory(),
+
+		balances:    make(map[string]map[string]int64),
+		denomTraces: make(map[string]transfertypes.DenomTrace),
+
+		acks:     make(map[uint64][]ibc.PacketAcknowledgement),
+		timeouts: make(map[uint64][]ibc.PacketTimeout),
+
+		observers: make(map[int]func()),
+	}
+}
+
+func unsupported(method string) error {
+	return fmt.Errorf("%s: %w", method, ErrNotSupported)
+}
+
+// timeAt returns the time of the block at height.
+func timeAt(height uint64) time.Time {
+	if height == 0 {
+		return GenesisTime
+	}
+	return GenesisTime.Add(time.Duration(height-1) * BlockTime)
+}
+
+func (c *Chain) Config() ibc.ChainConfig {
+	return c.cfg
+}
+
+// Initialize does nothing, as a mock chain needs neither Docker nor a home directory.
+func (c *Chain) Initialize(testName string, homeDirectory string, cli *client.Client, networkID string) error {
+	return nil
+}
+
+// Start credits the genesis wallets and produces the first block.
+func (c *Chain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.height > 0 {
+		return fmt.Errorf("chain %s is already started", c.cfg.ChainID)
+	}
+
+	for _, w := range additionalGenesisWallets {
+		c.credit(w.Address, w.Denom, w.Amount)
+	}
+	c.height = 1
+	return nil
+}
+
+func (c *Chain) Exec(ctx context.Context, cmd []string, env []string) (stdout, stderr []byte, err error) {
+	return nil, nil, unsupported("Exec")
+}
+
+func (c *Chain) ExportState(ctx context.Context, height int64) (string, error) {
+	return "", unsupported("ExportState")
+}
+
+func (c *Chain) GetRPCAddress() string {
+	return "mock://" + c.cfg.ChainID + "/rpc"
+}
+
+func (c *Chain) GetGRPCAddress() string {
+	return "mock://" + c.cfg.ChainID + "/grpc"
+}
+
+func (c *Chain) GetHostRPCAddress() string {
+	return c.GetRPCAddress()
+}
+
+func (c *Chain) GetHostGRPCAddress() string {
+	return c.GetGRPCAddress()
+}
+
+// HomeDir returns an empty string, as a mock chain has no home directory.
+func (c *Chain) HomeDir() string {
+	return ""
+}
+
+func (c *Chain) CreateKey(ctx context.Context, keyName string) error {
+	_, _, err := c.kr.NewMnemonic(keyName, keyring.English, hd.CreateHDPath(types.CoinType, 0, 0).String(), "", hd.Secp256k1)
+	if err != nil {
+		return fmt.Errorf("failed to create key %s: %w", keyName, err)
+	}
+	return nil
+}
+
+func (c *Chain) RecoverKey(ctx context.Context, name, mnemonic string) error {
+	_, err := c.kr.NewAccount(name, mnemonic, "", hd.CreateHDPath(types.CoinType, 0, 0).String(), hd.Secp256k1)
+	if err != nil {
+		return fmt.Errorf("failed to recover key %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Chain) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
+	info, err := c.kr.Key(keyName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key %s: %w", keyName, err)
+	}
+	return info.GetAddress().Bytes(), nil
+}
+
+// bech32Address returns the bech32 address of the key named keyName.
+func (c *Chain) bech32Address(keyName string) (string, error) {
+	addr, err := c.GetAddress(context.Background(), keyName)
+	if err != nil {
+		return "", err
+	}
+	return types.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, addr)
+}
+
+func (c *Chain) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	from, err := c.bech32Address(keyName)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.requireStarted(); err != nil {
+		return err
+	}
+	if err := c.debit(from, amount.Denom, amount.Amount); err != nil {
+		return err
+	}
+	c.credit(amount.Address, amount.Denom, amount.Amount)
+	c.commit()
+	return nil
+}
+
+func (c *Chain) InstantiateContract(ctx context.Context, keyName string, amount ibc.WalletAmount, fileName, initMessage string, needsNoAdminFlag bool) (string, error) {
+	return "", unsupported("InstantiateContract")
+}
+
+func (c *Chain) ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string) error {
+	return unsupported("ExecuteContract")
+}
+
+func (c *Chain) DumpContractState(ctx context.Context, contractAddress string, height int64) (*ibc.DumpContractStateResponse, error) {
+	return nil, unsupported("DumpContractState")
+}
+
+func (c *Chain) CreatePool(ctx context.Context, keyName string, contractAddress string, swapFee float64, exitFee float64, assets []ibc.WalletAmount) error {
+	return unsupported("CreatePool")
+}
+
+// Height produces an empty block and returns its height.
+func (c *Chain) Height(ctx context.Context) (uint64, error) {
+	if err := c.ProduceBlocks(1); err != nil {
+		return 0, err
+	}
+	return c.latestHeight(), nil
+}
+
+// ProduceBlocks produces n empty blocks,
+// notifying the relayers started on the chain after each one.
+func (c *Chain) ProduceBlocks(n int) error {
+	for i := 0; i < n; i++ {
+		c.mu.Lock()
+		if err := c.requireStarted(); err != nil {
+			c.mu.Unlock()
+			return err
+		}
+		c.height++
+		observers := make([]func(), 0, len(c.observers))
+		for _, o := range c.observers {
+			observers = append(observers, o)
+		}
+		c.mu.Unlock()
+
+		// Observers are called without holding the lock,
+		// so that they can submit transactions to the chain.
+		for _, o := range observers {
+			o()
+		}
+	}
+	return nil
+}
+
+// latestHeight returns the height of the latest block, without producing one.
+func (c *Chain) latestHeight() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.height
+}
+
+// subscribe registers f to be called after every empty block,
+// and returns a function that unregisters it.
+func (c *Chain) subscribe(f func()) (unsubscribe func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.nextObserverID
+	c.nextObserverID++
+	c.observers[id] = f
+
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.observers, id)
+	}
+}
+
+func (c *Chain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.balances[address][denom], nil
+}
+
+// GetGasFeesInNativeDenom returns zero, as mock chains do not charge fees.
+func (c *Chain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	return 0
+}
+
+func (c *Chain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ibc.PacketAcknowledgement(nil), c.acks[height]...), nil
+}
+
+func (c *Chain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]ibc.PacketTimeout(nil), c.timeouts[height]...), nil
+}
+
+func (c *Chain) Cleanup(ctx context.Context) error {
+	return nil
+}
+
+func (c *Chain) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error) {
+	return "", fmt.Errorf("RegisterInterchainAccount: %w", ibc.ErrInterchainAccountsNotSupported)
+}
+
+func (c *Chain) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
+	return unsupported("SendICABankTransfer")
+}
+
+func (c *Chain) QueryInterchainAccount(ctx context.Context, connectionID, address string) (string, error) {
+	return "", unsupported("QueryInterchainAccount")
+}
+
+// requireStarted returns an error if the chain has not been started.
+// The caller must hold c.mu.
+func (c *Chain) requireStarted() error {
+	if c.height == 0 {
+		return fmt.Errorf("chain %s is not started", c.cfg.ChainID)
+	}
+	return nil
+}
+
+// commit commits a transaction in a new block, and returns the block height and the transaction hash.
+// The caller must hold c.mu.
+func (c *Chain) commit() (height uint64, txHash string) {
+	c.height++
+	c.txCount++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%d/%d", c.cfg.ChainID, c.height, c.txCount)))
+	return c.height, fmt.Sprintf("%X", sum)
+}
+
+// credit adds amount of denom to the balance of address.
+// The caller must hold c.mu.
+func (c *Chain) credit(address, denom string, amount int64) {
+	if c.balances[address] == nil {
+		c.balances[address] = make(map[string]int64)
+	}
+	c.balances[address][denom] += amount
+}
+
+// debit subtracts amount of denom from the balance of address.
+// The caller must hold c.mu.
+func (c *Chain) debit(address, denom string, amount int64) error {
+	if amount < 0 {
+		return fmt.Errorf("invalid amount %d", amount)
+	}
+	if bal := c.balances[address][denom]; bal < amount {
+		return fmt.Errorf("insufficient funds: %s has %d%s, need %d%s", address, bal, denom, amount, denom)
+	}
+	c.credit(address, denom, -amount)
+	return nil
+}
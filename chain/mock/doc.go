@@ -0,0 +1,15 @@
+// Package mock provides an in-memory implementation of ibc.Chain, and an ibc.Relayer to pair it with,
+// for unit testing test scenarios and orchestration code without Docker.
+//
+// A mock Chain keeps its heights, balances, keys, and IBC clients, connections, channels and packets in memory.
+// It produces a block only when driven: every transaction is committed in a block of its own,
+// every call to Height produces an empty block before reporting the new height,
+// and ProduceBlocks produces any number of empty blocks.
+// Because Height advances the chain, helpers that poll Height,
+// such as test.WaitForBlocks and test.PollForAck, make progress deterministically.
+//
+// A mock Relayer started through StartRelayer relays every time one of its chains produces an empty block,
+// and it relays synchronously through FlushPackets and FlushAcknowledgements.
+// Only ICS-20 fungible token transfers are supported;
+// tokens sent over IBC are burned and minted rather than escrowed.
+package mock
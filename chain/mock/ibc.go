@@ -0,0 +1,585 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+const (
+	// defaultTimeoutHeight is the timeout of a transfer in blocks of the counterparty,
+	// relative to its latest height, when the transfer does not specify a timeout.
+	// It matches transfertypes.DefaultRelativePacketTimeoutHeight.
+	defaultTimeoutHeight = 1000
+
+	// defaultTimeoutDuration is the timeout of a transfer relative to the time of the counterparty's latest block,
+	// when the transfer does not specify a timeout.
+	// It matches transfertypes.DefaultRelativePacketTimeoutTimestamp.
+	defaultTimeoutDuration = 10 * time.Minute
+)
+
+// errTimedOut is returned when receiving a packet that has timed out.
+var errTimedOut = errors.New("packet timed out")
+
+// clientState is a light client on a mock chain tracking its counterparty.
+type clientState struct {
+	ID           string
+	Counterparty *Chain
+	LatestHeight uint64
+}
+
+// connection is a connection end on a mock chain.
+// Connections are opened in a single step, without a handshake.
+type connection struct {
+	ID       string
+	ClientID string
+
+	CounterpartyClientID     string
+	CounterpartyConnectionID string
+}
+
+// channel is a channel end on a mock chain.
+// Channels are opened in a single step, without a handshake.
+type channel struct {
+	PortID, ID   string
+	ConnectionID string
+	Order        chantypes.Order
+	Version      string
+	State        chantypes.State
+
+	CounterpartyPortID, CounterpartyChannelID string
+	Counterparty                              *Chain
+
+	nextSequence uint64
+
+	// Packets sent on the channel that have been neither acknowledged nor timed out, keyed by sequence.
+	commitments map[uint64]ibc.Packet
+
+	// Acknowledgements written for packets received on the channel, keyed by sequence.
+	receipts map[uint64][]byte
+}
+
+// client returns the client with the given ID.
+// The caller must hold c.mu.
+func (c *Chain) client(clientID string) (*clientState, error) {
+	for _, cl := range c.clients {
+		if cl.ID == clientID {
+			return cl, nil
+		}
+	}
+	return nil, fmt.Errorf("client %s not found on chain %s", clientID, c.cfg.ChainID)
+}
+
+// connection returns the connection with the given ID.
+// The caller must hold c.mu.
+func (c *Chain) connection(connectionID string) (*connection, error) {
+	for _, conn := range c.connections {
+		if conn.ID == connectionID {
+			return conn, nil
+		}
+	}
+	return nil, fmt.Errorf("connection %s not found on chain %s", connectionID, c.cfg.ChainID)
+}
+
+// channel returns the channel with the given ID.
+// The caller must hold c.mu.
+func (c *Chain) channel(channelID string) (*channel, error) {
+	for _, ch := range c.channels {
+		if ch.ID == channelID {
+			return ch, nil
+		}
+	}
+	return nil, fmt.Errorf("channel %s not found on chain %s", channelID, c.cfg.ChainID)
+}
+
+// createClient creates a client tracking counterparty, and returns its ID.
+func (c *Chain) createClient(counterparty *Chain) (string, error) {
+	cpHeight := counterparty.latestHeight()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.requireStarted(); err != nil {
+		return "", err
+	}
+
+	cl := &clientState{
+		ID:           fmt.Sprintf("07-tendermint-%d", len(c.clients)),
+		Counterparty: counterparty,
+		LatestHeight: cpHeight,
+	}
+	c.clients = append(c.clients, cl)
+	c.commit()
+	return cl.ID, nil
+}
+
+// updateClient updates the client with the given ID to the latest height of its counterparty.
+func (c *Chain) updateClient(clientID string) error {
+	c.mu.Lock()
+	cl, err := c.client(clientID)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	cpHeight := cl.Counterparty.latestHeight()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cl.LatestHeight = cpHeight
+	c.commit()
+	return nil
+}
+
+// addConnection opens a connection on the client with the given ID, and returns its ID.
+func (c *Chain) addConnection(clientID, counterpartyClientID, counterpartyConnectionID string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.client(clientID); err != nil {
+		return "", err
+	}
+
+	conn := &connection{
+		ID:       fmt.Sprintf("connection-%d", len(c.connections)),
+		ClientID: clientID,
+
+		CounterpartyClientID:     counterpartyClientID,
+		CounterpartyConnectionID: counterpartyConnectionID,
+	}
+	c.connections = append(c.connections, conn)
+	c.commit()
+	return conn.ID, nil
+}
+
+// setConnectionCounterparty records the ID of the counterparty of the connection with the given ID.
+func (c *Chain) setConnectionCounterparty(connectionID, counterpartyConnectionID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	conn, err := c.connection(connectionID)
+	if err != nil {
+		return err
+	}
+	conn.CounterpartyConnectionID = counterpartyConnectionID
+	return nil
+}
+
+// addChannel opens a channel on the connection with the given ID, and returns its ID.
+func (c *Chain) addChannel(connectionID, portID string, counterparty *Chain, counterpartyPortID, counterpartyChannelID string, opts ibc.CreateChannelOptions) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := c.connection(connectionID); err != nil {
+		return "", err
+	}
+
+	order := chantypes.UNORDERED
+	if opts.Order == ibc.Ordered {
+		order = chantypes.ORDERED
+	}
+	ch := &channel{
+		PortID:       portID,
+		ID:           fmt.Sprintf("channel-%d", len(c.channels)),
+		ConnectionID: connectionID,
+		Order:        order,
+		Version:      opts.Version,
+		State:        chantypes.OPEN,
+
+		CounterpartyPortID:    counterpartyPortID,
+		CounterpartyChannelID: counterpartyChannelID,
+		Counterparty:          counterparty,
+
+		nextSequence: 1,
+		commitments:  make(map[uint64]ibc.Packet),
+		receipts:     make(map[uint64][]byte),
+	}
+	c.channels = append(c.channels, ch)
+	c.commit()
+	return ch.ID, nil
+}
+
+// setChannelCounterparty records the ID of the counterparty of the channel with the given ID.
+func (c *Chain) setChannelCounterparty(channelID, counterpartyChannelID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(channelID)
+	if err != nil {
+		return err
+	}
+	ch.CounterpartyChannelID = counterpartyChannelID
+	return nil
+}
+
+// closeChannel closes the channel with the given ID.
+func (c *Chain) closeChannel(channelID string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(channelID)
+	if err != nil {
+		return err
+	}
+	if ch.State != chantypes.CLOSED {
+		ch.State = chantypes.CLOSED
+		c.commit()
+	}
+	return nil
+}
+
+// channelOnConnection reports the counterparty of the channel with the given ID,
+// and whether the channel is open on the connection with the given ID.
+func (c *Chain) channelOnConnection(channelID, connectionID string) (counterpartyChannelID string, open bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(channelID)
+	if err != nil {
+		return "", false, err
+	}
+	if ch.ConnectionID != connectionID {
+		return "", false, fmt.Errorf("channel %s is not on connection %s", channelID, connectionID)
+	}
+	return ch.CounterpartyChannelID, ch.State == chantypes.OPEN, nil
+}
+
+// channelsOnConnection returns the IDs of the channels on the connection with the given ID.
+func (c *Chain) channelsOnConnection(connectionID string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var ids []string
+	for _, ch := range c.channels {
+		if ch.ConnectionID == connectionID {
+			ids = append(ids, ch.ID)
+		}
+	}
+	return ids
+}
+
+func (c *Chain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, timeout *ibc.IBCTimeout) (ibc.Tx, error) {
+	sender, err := c.bech32Address(keyName)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+
+	c.mu.Lock()
+	ch, err := c.channel(channelID)
+	c.mu.Unlock()
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+
+	// Timeouts are relative to the counterparty's latest block.
+	// Read its height before locking c, as the counterparty may be relaying to c.
+	cpHeight := ch.Counterparty.latestHeight()
+	timeoutHeight := cpHeight + defaultTimeoutHeight
+	timeoutTimestamp := timeAt(cpHeight).Add(defaultTimeoutDuration)
+	if timeout != nil {
+		if timeout.NanoSeconds > 0 {
+			timeoutTimestamp = timeAt(cpHeight).Add(time.Duration(timeout.NanoSeconds))
+		} else if timeout.Height > 0 {
+			timeoutHeight = cpHeight + timeout.Height
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.requireStarted(); err != nil {
+		return ibc.Tx{}, err
+	}
+	if ch.State != chantypes.OPEN {
+		return ibc.Tx{}, fmt.Errorf("channel %s is not open", channelID)
+	}
+	if ch.PortID != transfertypes.PortID {
+		return ibc.Tx{}, fmt.Errorf("channel %s is not bound to the %s port", channelID, transfertypes.PortID)
+	}
+
+	// Vouchers are sent with their full denom path, so that the receiver can unwind them.
+	denomPath := amount.Denom
+	if strings.HasPrefix(amount.Denom, transfertypes.DenomPrefix+"/") {
+		trace, ok := c.denomTraces[amount.Denom]
+		if !ok {
+			return ibc.Tx{}, fmt.Errorf("unknown denom %s", amount.Denom)
+		}
+		denomPath = trace.GetFullDenomPath()
+	}
+
+	if err := c.debit(sender, amount.Denom, amount.Amount); err != nil {
+		return ibc.Tx{}, err
+	}
+
+	data := transfertypes.NewFungibleTokenPacketData(denomPath, strconv.FormatInt(amount.Amount, 10), sender, amount.Address)
+	packet := ibc.Packet{
+		Sequence:         ch.nextSequence,
+		SourcePort:       ch.PortID,
+		SourceChannel:    ch.ID,
+		DestPort:         ch.CounterpartyPortID,
+		DestChannel:      ch.CounterpartyChannelID,
+		Data:             data.GetBytes(),
+		TimeoutHeight:    clienttypes.NewHeight(0, timeoutHeight).String(),
+		TimeoutTimestamp: ibc.Nanoseconds(timeoutTimestamp.UnixNano()),
+	}
+	ch.nextSequence++
+	ch.commitments[packet.Sequence] = packet
+
+	height, txHash := c.commit()
+	return ibc.Tx{
+		Height:   height,
+		TxHash:   txHash,
+		GasSpent: GasSpent,
+		Packet:   packet,
+	}, nil
+}
+
+// pendingPackets returns the packets sent on the channel with the given ID
+// that have been neither acknowledged nor timed out, in order of sequence.
+func (c *Chain) pendingPackets(channelID string) ([]ibc.Packet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	packets := make([]ibc.Packet, 0, len(ch.commitments))
+	for _, p := range ch.commitments {
+		packets = append(packets, p)
+	}
+	sort.Slice(packets, func(i, j int) bool { return packets[i].Sequence < packets[j].Sequence })
+	return packets, nil
+}
+
+// receipt returns the acknowledgement written for the packet, if it has been received.
+func (c *Chain) receipt(p ibc.Packet) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(p.DestChannel)
+	if err != nil {
+		return nil, false
+	}
+	ack, ok := ch.receipts[p.Sequence]
+	return ack, ok
+}
+
+// recvPacket receives the packet and returns the acknowledgement written for it.
+// It returns errTimedOut if the packet can no longer be received.
+// Receiving a packet more than once returns the acknowledgement written the first time.
+func (c *Chain) recvPacket(p ibc.Packet) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(p.DestChannel)
+	if err != nil {
+		return nil, err
+	}
+	if ack, ok := ch.receipts[p.Sequence]; ok {
+		return ack, nil
+	}
+	if ch.State != chantypes.OPEN {
+		return nil, fmt.Errorf("channel %s is not open", ch.ID)
+	}
+
+	timeoutHeight, err := clienttypes.ParseHeight(p.TimeoutHeight)
+	if err != nil {
+		return nil, fmt.Errorf("invalid packet timeout height: %w", err)
+	}
+	if !timeoutHeight.IsZero() && c.height >= timeoutHeight.RevisionHeight {
+		return nil, errTimedOut
+	}
+	if p.TimeoutTimestamp > 0 && uint64(timeAt(c.height).UnixNano()) >= uint64(p.TimeoutTimestamp) {
+		return nil, errTimedOut
+	}
+
+	var ack chantypes.Acknowledgement
+	if err := c.receiveTransfer(p); err != nil {
+		ack = chantypes.NewErrorAcknowledgement(err.Error())
+	} else {
+		ack = chantypes.NewResultAcknowledgement([]byte{byte(1)})
+	}
+	ch.receipts[p.Sequence] = ack.Acknowledgement()
+	c.commit()
+	return ch.receipts[p.Sequence], nil
+}
+
+// receiveTransfer credits the receiver of the fungible token transfer in p.
+// The caller must hold c.mu.
+func (c *Chain) receiveTransfer(p ibc.Packet) error {
+	if p.DestPort != transfertypes.PortID {
+		return fmt.Errorf("unsupported port %s", p.DestPort)
+	}
+
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(p.Data, &data); err != nil {
+		return fmt.Errorf("invalid packet data: %w", err)
+	}
+	amount, err := strconv.ParseInt(data.Amount, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid amount %q: %w", data.Amount, err)
+	}
+
+	var denomPath string
+	if transfertypes.ReceiverChainIsSource(p.SourcePort, p.SourceChannel, data.Denom) {
+		// The tokens are returning, so remove the prefix added when they were sent.
+		denomPath = data.Denom[len(transfertypes.GetDenomPrefix(p.SourcePort, p.SourceChannel)):]
+	} else {
+		denomPath = transfertypes.GetPrefixedDenom(p.DestPort, p.DestChannel, data.Denom)
+	}
+
+	trace := transfertypes.ParseDenomTrace(denomPath)
+	if trace.Path != "" {
+		c.denomTraces[trace.IBCDenom()] = trace
+	}
+	c.credit(data.Receiver, trace.IBCDenom(), amount)
+	return nil
+}
+
+// acknowledgePacket removes the commitment of the packet, refunding the sender if ack is an error.
+// Acknowledging a packet more than once has no effect.
+func (c *Chain) acknowledgePacket(p ibc.Packet, ack []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(p.SourceChannel)
+	if err != nil {
+		return err
+	}
+	if _, ok := ch.commitments[p.Sequence]; !ok {
+		return nil
+	}
+
+	var a chantypes.Acknowledgement
+	if err := chantypes.SubModuleCdc.UnmarshalJSON(ack, &a); err != nil {
+		return fmt.Errorf("invalid acknowledgement: %w", err)
+	}
+	if !a.Success() {
+		c.refund(p)
+	}
+
+	delete(ch.commitments, p.Sequence)
+	height, _ := c.commit()
+	c.acks[height] = append(c.acks[height], ibc.PacketAcknowledgement{Packet: p, Acknowledgement: ack})
+	return nil
+}
+
+// timeoutPacket removes the commitment of the packet and refunds the sender.
+// Timing out a packet on an ordered channel closes the channel.
+// Timing out a packet more than once has no effect.
+func (c *Chain) timeoutPacket(p ibc.Packet) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch, err := c.channel(p.SourceChannel)
+	if err != nil {
+		return err
+	}
+	if _, ok := ch.commitments[p.Sequence]; !ok {
+		return nil
+	}
+
+	c.refund(p)
+	delete(ch.commitments, p.Sequence)
+	if ch.Order == chantypes.ORDERED {
+		ch.State = chantypes.CLOSED
+	}
+	height, _ := c.commit()
+	c.timeouts[height] = append(c.timeouts[height], ibc.PacketTimeout{Packet: p})
+	return nil
+}
+
+// refund credits the sender of the fungible token transfer in p.
+// The caller must hold c.mu.
+func (c *Chain) refund(p ibc.Packet) {
+	var data transfertypes.FungibleTokenPacketData
+	if err := transfertypes.ModuleCdc.UnmarshalJSON(p.Data, &data); err != nil {
+		// Only this chain sends packets, so the data is well-formed.
+		panic(fmt.Errorf("invalid packet data: %w", err))
+	}
+	amount, err := strconv.ParseInt(data.Amount, 10, 64)
+	if err != nil {
+		panic(fmt.Errorf("invalid amount %q: %w", data.Amount, err))
+	}
+	c.credit(data.Sender, transfertypes.ParseDenomTrace(data.Denom).IBCDenom(), amount)
+}
+
+// channelOutputs returns every channel on the chain.
+func (c *Chain) channelOutputs() []ibc.ChannelOutput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]ibc.ChannelOutput, len(c.channels))
+	for i, ch := range c.channels {
+		out[i] = ibc.ChannelOutput{
+			State:    ch.State.String(),
+			Ordering: ch.Order.String(),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.CounterpartyPortID,
+				ChannelID: ch.CounterpartyChannelID,
+			},
+			ConnectionHops: []string{ch.ConnectionID},
+			Version:        ch.Version,
+			PortID:         ch.PortID,
+			ChannelID:      ch.ID,
+		}
+	}
+	return out
+}
+
+// connectionOutputs returns every connection on the chain.
+func (c *Chain) connectionOutputs() ibc.ConnectionOutputs {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(ibc.ConnectionOutputs, len(c.connections))
+	for i, conn := range c.connections {
+		out[i] = &ibc.ConnectionOutput{
+			ID:       conn.ID,
+			ClientID: conn.ClientID,
+			Versions: conntypes.ExportedVersionsToProto(conntypes.GetCompatibleVersions()),
+			State:    conntypes.OPEN.String(),
+			Counterparty: &conntypes.Counterparty{
+				ClientId:     conn.CounterpartyClientID,
+				ConnectionId: conn.CounterpartyConnectionID,
+			},
+			DelayPeriod: "0",
+		}
+	}
+	return out
+}
+
+// clientOutputs returns every client on the chain.
+func (c *Chain) clientOutputs() []ibc.ClientOutput {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]ibc.ClientOutput, len(c.clients))
+	for i, cl := range c.clients {
+		out[i] = ibc.ClientOutput{
+			ID:             cl.ID,
+			ChainID:        cl.Counterparty.cfg.ChainID,
+			TrustingPeriod: trustingPeriod(cl.Counterparty.cfg),
+			LatestHeight:   clienttypes.NewHeight(0, cl.LatestHeight),
+		}
+	}
+	return out
+}
+
+// trustingPeriod returns the trusting period configured for the chain, or zero if it is not set or invalid.
+func trustingPeriod(cfg ibc.ChainConfig) time.Duration {
+	d, _ := time.ParseDuration(cfg.TrustingPeriod)
+	return d
+}
@@ -0,0 +1,205 @@
+package mock_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest/chain/mock"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// fundedChain returns a started mock chain with a key named "user" holding 1000 of the chain's denom.
+func fundedChain(t *testing.T, chainID, prefix, denom string) (*mock.Chain, string) {
+	t.Helper()
+
+	ctx := context.Background()
+	c := mock.NewChain(ibc.ChainConfig{ChainID: chainID, Bech32Prefix: prefix, Denom: denom})
+	require.NoError(t, c.CreateKey(ctx, "user"))
+	addr, err := c.GetAddress(ctx, "user")
+	require.NoError(t, err)
+	bech32 := types.MustBech32ifyAddressBytes(prefix, addr)
+
+	require.NoError(t, c.Start(t.Name(), ctx, ibc.WalletAmount{Address: bech32, Denom: denom, Amount: 1000}))
+	return c, bech32
+}
+
+// linkedChains returns two funded mock chains and a relayer that has linked them on path "p".
+func linkedChains(t *testing.T) (c0, c1 *mock.Chain, user0, user1 string, r *mock.Relayer) {
+	t.Helper()
+
+	c0, user0 = fundedChain(t, "c0", "cosmos", "uatom")
+	c1, user1 = fundedChain(t, "c1", "osmo", "uosmo")
+
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	r = mock.NewRelayer(c0, c1)
+	for _, c := range []*mock.Chain{c0, c1} {
+		require.NoError(t, r.AddChainConfiguration(ctx, rep, c.Config(), "relayer", c.GetRPCAddress(), c.GetGRPCAddress()))
+	}
+	require.NoError(t, r.GeneratePath(ctx, rep, "c0", "c1", "p", ibc.PathOptions{}))
+	require.NoError(t, r.LinkPath(ctx, rep, "p", ibc.DefaultChannelOpts()))
+	return c0, c1, user0, user1, r
+}
+
+func TestChain_Height(t *testing.T) {
+	ctx := context.Background()
+
+	c := mock.NewChain(ibc.ChainConfig{ChainID: "c0", Bech32Prefix: "cosmos", Denom: "uatom"})
+	_, err := c.Height(ctx)
+	require.Error(t, err, "chain that is not started must not report a height")
+
+	require.NoError(t, c.Start(t.Name(), ctx))
+
+	h, err := c.Height(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), h, "height must produce a block")
+
+	require.NoError(t, c.ProduceBlocks(3))
+	require.NoError(t, test.WaitForBlocks(ctx, 2, c))
+	h, err = c.Height(ctx)
+	require.NoError(t, err)
+	require.Greater(t, h, uint64(7))
+}
+
+func TestChain_SendFunds(t *testing.T) {
+	ctx := context.Background()
+	c, user := fundedChain(t, "c0", "cosmos", "uatom")
+
+	require.NoError(t, c.RecoverKey(ctx, "other", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"))
+	addr, err := c.GetAddress(ctx, "other")
+	require.NoError(t, err)
+	other := types.MustBech32ifyAddressBytes("cosmos", addr)
+
+	require.NoError(t, c.SendFunds(ctx, "user", ibc.WalletAmount{Address: other, Denom: "uatom", Amount: 400}))
+	require.Error(t, c.SendFunds(ctx, "user", ibc.WalletAmount{Address: other, Denom: "uatom", Amount: 601}))
+
+	bal, err := c.GetBalance(ctx, user, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(600), bal)
+
+	bal, err = c.GetBalance(ctx, other, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(400), bal)
+}
+
+func TestRelayer_Transfer(t *testing.T) {
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	c0, c1, user0, user1, r := linkedChains(t)
+
+	channels, err := r.GetChannels(ctx, rep, "c0")
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	require.Equal(t, chantypes.OPEN.String(), channels[0].State)
+	channel := channels[0]
+
+	tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, "user", ibc.WalletAmount{Address: user1, Denom: "uatom", Amount: 100}, nil)
+	require.NoError(t, err)
+	require.NoError(t, tx.Validate())
+
+	// Nothing is relayed until the relayer is flushed or started.
+	_, err = test.PollForAck(ctx, c0, tx.Height, tx.Height+5, tx.Packet)
+	require.ErrorIs(t, err, test.ErrNotFound)
+
+	require.NoError(t, r.FlushPackets(ctx, rep, "p", channel.ChannelID))
+	require.NoError(t, r.FlushAcknowledgements(ctx, rep, "p", channel.ChannelID))
+	ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+20, tx.Packet)
+	require.NoError(t, err)
+	require.NoError(t, ack.Validate())
+
+	ibcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, "uatom"),
+	).IBCDenom()
+	bal, err := c1.GetBalance(ctx, user1, ibcDenom)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), bal)
+
+	// Sending the vouchers back through a started relayer restores the original denom.
+	require.NoError(t, r.StartRelayer(ctx, rep, "p"))
+	defer func() { require.NoError(t, r.StopRelayer(ctx, rep)) }()
+
+	tx, err = c1.SendIBCTransfer(ctx, channel.Counterparty.ChannelID, "user", ibc.WalletAmount{Address: user0, Denom: ibcDenom, Amount: 100}, nil)
+	require.NoError(t, err)
+	_, err = test.PollForAck(ctx, c1, tx.Height, tx.Height+10, tx.Packet)
+	require.NoError(t, err)
+
+	bal, err = c1.GetBalance(ctx, user1, ibcDenom)
+	require.NoError(t, err)
+	require.Zero(t, bal)
+
+	bal, err = c0.GetBalance(ctx, user0, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), bal)
+}
+
+func TestRelayer_Timeout(t *testing.T) {
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	c0, c1, user0, user1, r := linkedChains(t)
+
+	tx, err := c0.SendIBCTransfer(ctx, "channel-0", "user", ibc.WalletAmount{Address: user1, Denom: "uatom", Amount: 100}, &ibc.IBCTimeout{Height: 2})
+	require.NoError(t, err)
+
+	bal, err := c0.GetBalance(ctx, user0, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(900), bal)
+
+	// Let the packet time out on the counterparty before relaying it.
+	require.NoError(t, c1.ProduceBlocks(2))
+	require.NoError(t, r.StartRelayer(ctx, rep, "p"))
+	defer func() { require.NoError(t, r.StopRelayer(ctx, rep)) }()
+
+	timeout, err := test.PollForTimeout(ctx, c0, tx.Height, tx.Height+10, tx.Packet)
+	require.NoError(t, err)
+	require.NoError(t, timeout.Validate())
+
+	bal, err = c0.GetBalance(ctx, user0, "uatom")
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), bal, "sender must be refunded")
+}
+
+func TestRelayer_PacketFilter(t *testing.T) {
+	ctx := context.Background()
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	c0, _, _, user1, r := linkedChains(t)
+
+	// Link a second path, with two channels, that relays only on the second one.
+	// The first path already holds channel-0.
+	require.NoError(t, r.GeneratePath(ctx, rep, "c0", "c1", "filtered", ibc.PathOptions{
+		PacketFilter: ibc.PacketFilter{Policy: ibc.PacketFilterAllowList, ChannelIDs: []string{"channel-2"}},
+	}))
+	require.NoError(t, r.LinkPath(ctx, rep, "filtered", ibc.DefaultChannelOpts()))
+	require.NoError(t, r.CreateChannel(ctx, rep, "filtered", ibc.DefaultChannelOpts()))
+
+	clients, err := r.GetClients(ctx, rep, "c0")
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+	connections, err := r.GetConnections(ctx, rep, "c0")
+	require.NoError(t, err)
+	require.Len(t, connections, 2)
+	channels, err := r.GetChannels(ctx, rep, "c0")
+	require.NoError(t, err)
+	require.Len(t, channels, 3)
+
+	require.NoError(t, r.StartRelayer(ctx, rep, "filtered"))
+	defer func() { require.NoError(t, r.StopRelayer(ctx, rep)) }()
+
+	sendTransfer := func(channelID string) ibc.Tx {
+		tx, err := c0.SendIBCTransfer(ctx, channelID, "user", ibc.WalletAmount{Address: user1, Denom: "uatom", Amount: 1}, nil)
+		require.NoError(t, err)
+		return tx
+	}
+	deniedTx := sendTransfer("channel-1")
+	allowedTx := sendTransfer("channel-2")
+
+	_, err = test.PollForAck(ctx, c0, allowedTx.Height, allowedTx.Height+10, allowedTx.Packet)
+	require.NoError(t, err)
+	_, err = test.PollForAck(ctx, c0, deniedTx.Height, deniedTx.Height+10, deniedTx.Packet)
+	require.ErrorIs(t, err, test.ErrNotFound)
+}
@@ -0,0 +1,651 @@
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+)
+
+// Relayer is an ibc.Relayer that relays between mock chains.
+type Relayer struct {
+	mu sync.Mutex
+
+	// Every chain the relayer may be configured for, keyed by chain ID.
+	chains map[string]*Chain
+
+	// Chains added through AddChainConfiguration, keyed by chain ID.
+	configured map[string]bool
+
+	kr      keyring.Keyring
+	wallets map[string]ibc.RelayerWallet
+	paths   map[string]*path
+
+	// Set between StartRelayer and StopRelayer.
+	pathNames   []string
+	paused      bool
+	unsubscribe []func()
+}
+
+// path records the chains and the IBC objects that the relayer relays between.
+type path struct {
+	Src, Dst *Chain
+
+	// Client on Src tracking Dst, and client on Dst tracking Src.
+	SrcClientID, DstClientID string
+
+	SrcConnectionID, DstConnectionID string
+
+	PacketFilter ibc.PacketFilter
+}
+
+var (
+	_ ibc.FaultInjectableRelayer = (*Relayer)(nil)
+	_ ibc.PathSharingRelayer     = (*Relayer)(nil)
+)
+
+// NewRelayer returns a relayer that can be configured for any of chains.
+func NewRelayer(chains ...*Chain) *Relayer {
+	r := &Relayer{
+		chains:     make(map[string]*Chain, len(chains)),
+		configured: make(map[string]bool, len(chains)),
+
+		kr:      keyring.NewInMemory(),
+		wallets: make(map[string]ibc.RelayerWallet),
+		paths:   make(map[string]*path),
+	}
+	for _, c := range chains {
+		r.chains[c.cfg.ChainID] = c
+	}
+	return r
+}
+
+// UseDockerNetwork reports false, as the relayer runs inside the test process.
+func (r *Relayer) UseDockerNetwork() bool {
+	return false
+}
+
+// track reports an operation of the relayer to rep.
+// There is no container or process, so the command is a description of the operation.
+func (r *Relayer) track(rep ibc.RelayerExecReporter, startedAt time.Time, stdout string, err error, command ...string) {
+	relayer.TrackOperation(rep, "mock", startedAt, stdout, err, command...)
+}
+
+// chain returns the configured chain with the given ID.
+// The caller must hold r.mu.
+func (r *Relayer) chain(chainID string) (*Chain, error) {
+	if !r.configured[chainID] {
+		return nil, fmt.Errorf("chain %s has not been configured", chainID)
+	}
+	return r.chains[chainID], nil
+}
+
+// path returns a copy of the named path.
+func (r *Relayer) path(pathName string) (path, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	p, ok := r.paths[pathName]
+	if !ok {
+		return path{}, fmt.Errorf("path %s has not been generated", pathName)
+	}
+	return *p, nil
+}
+
+func (r *Relayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "add-chain", chainConfig.ChainID) }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.chains[chainConfig.ChainID]; !ok {
+		return fmt.Errorf("chain %s was not passed to NewRelayer", chainConfig.ChainID)
+	}
+	if r.configured[chainConfig.ChainID] {
+		return fmt.Errorf("chain %s is already configured", chainConfig.ChainID)
+	}
+	r.configured[chainConfig.ChainID] = true
+	return nil
+}
+
+func (r *Relayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, mnemonic string) (err error) {
+	startedAt := time.Now()
+	var addr string
+	defer func() { r.track(rep, startedAt, addr, err, "restore-key", chainID, keyName) }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return err
+	}
+	info, err := r.kr.NewAccount(chainID+"/"+keyName, mnemonic, "", hd.CreateHDPath(types.CoinType, 0, 0).String(), hd.Secp256k1)
+	if err != nil {
+		return fmt.Errorf("failed to restore key %s for chain %s: %w", keyName, chainID, err)
+	}
+	addr, err = types.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+	if err != nil {
+		return err
+	}
+
+	r.wallets[chainID] = ibc.RelayerWallet{Mnemonic: mnemonic, Address: addr}
+	return nil
+}
+
+func (r *Relayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName string) (wallet ibc.RelayerWallet, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, wallet.Address, err, "add-key", chainID, keyName) }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return wallet, err
+	}
+	info, mnemonic, err := r.kr.NewMnemonic(chainID+"/"+keyName, keyring.English, hd.CreateHDPath(types.CoinType, 0, 0).String(), "", hd.Secp256k1)
+	if err != nil {
+		return wallet, fmt.Errorf("failed to add key %s for chain %s: %w", keyName, chainID, err)
+	}
+	addr, err := types.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+	if err != nil {
+		return wallet, err
+	}
+
+	wallet = ibc.RelayerWallet{Mnemonic: mnemonic, Address: addr}
+	r.wallets[chainID] = wallet
+	return wallet, nil
+}
+
+func (r *Relayer) GetWallet(chainID string) (ibc.RelayerWallet, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.wallets[chainID]
+	return w, ok
+}
+
+func (r *Relayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.PathOptions) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "generate-path", srcChainID, dstChainID, pathName) }()
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid path options: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	src, err := r.chain(srcChainID)
+	if err != nil {
+		return err
+	}
+	dst, err := r.chain(dstChainID)
+	if err != nil {
+		return err
+	}
+	r.paths[pathName] = &path{Src: src, Dst: dst, PacketFilter: opts.PacketFilter}
+	return nil
+}
+
+func (r *Relayer) GenerateSharedPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) (err error) {
+	startedAt := time.Now()
+	defer func() {
+		r.track(rep, startedAt, "", err, "generate-shared-path", src.ChainID, dst.ChainID, pathName, src.ConnectionID, dst.ConnectionID)
+	}()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	srcChain, err := r.chain(src.ChainID)
+	if err != nil {
+		return err
+	}
+	dstChain, err := r.chain(dst.ChainID)
+	if err != nil {
+		return err
+	}
+	r.paths[pathName] = &path{
+		Src: srcChain, Dst: dstChain,
+
+		SrcClientID: src.ClientID, DstClientID: dst.ClientID,
+
+		SrcConnectionID: src.ConnectionID, DstConnectionID: dst.ConnectionID,
+	}
+	return nil
+}
+
+func (r *Relayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	if err := r.CreateClients(ctx, rep, pathName); err != nil {
+		return err
+	}
+	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+	return r.CreateChannel(ctx, rep, pathName, opts)
+}
+
+func (r *Relayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "create-clients", pathName) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	srcClientID, err := p.Src.createClient(p.Dst)
+	if err != nil {
+		return err
+	}
+	dstClientID, err := p.Dst.createClient(p.Src)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rp := r.paths[pathName]
+	rp.SrcClientID, rp.DstClientID = srcClientID, dstClientID
+	return nil
+}
+
+func (r *Relayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "create-connections", pathName) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcClientID == "" || p.DstClientID == "" {
+		return fmt.Errorf("path %s has no clients; call CreateClients first", pathName)
+	}
+
+	srcConnID, err := p.Src.addConnection(p.SrcClientID, p.DstClientID, "")
+	if err != nil {
+		return err
+	}
+	dstConnID, err := p.Dst.addConnection(p.DstClientID, p.SrcClientID, srcConnID)
+	if err != nil {
+		return err
+	}
+	if err := p.Src.setConnectionCounterparty(srcConnID, dstConnID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rp := r.paths[pathName]
+	rp.SrcConnectionID, rp.DstConnectionID = srcConnID, dstConnID
+	return nil
+}
+
+func (r *Relayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (err error) {
+	startedAt := time.Now()
+	defer func() {
+		r.track(rep, startedAt, "", err, "create-channel", pathName, opts.SourcePortName, opts.DestPortName)
+	}()
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcConnectionID == "" || p.DstConnectionID == "" {
+		return fmt.Errorf("path %s has no connection; call CreateConnections first", pathName)
+	}
+
+	srcChanID, err := p.Src.addChannel(p.SrcConnectionID, opts.SourcePortName, p.Dst, opts.DestPortName, "", opts)
+	if err != nil {
+		return err
+	}
+	dstChanID, err := p.Dst.addChannel(p.DstConnectionID, opts.DestPortName, p.Src, opts.SourcePortName, srcChanID, opts)
+	if err != nil {
+		return err
+	}
+	return p.Src.setChannelCounterparty(srcChanID, dstChanID)
+}
+
+func (r *Relayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "close-channel", pathName, channelID) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	cpChannelID, _, err := p.Src.channelOnConnection(channelID, p.SrcConnectionID)
+	if err != nil {
+		return err
+	}
+	if err := p.Src.closeChannel(channelID); err != nil {
+		return err
+	}
+	return p.Dst.closeChannel(cpChannelID)
+}
+
+func (r *Relayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "update-clients", pathName) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcClientID == "" || p.DstClientID == "" {
+		return fmt.Errorf("path %s has no clients; call CreateClients first", pathName)
+	}
+
+	if err := p.Src.updateClient(p.SrcClientID); err != nil {
+		return err
+	}
+	return p.Dst.updateClient(p.DstClientID)
+}
+
+func (r *Relayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (channels []ibc.ChannelOutput, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-channels", chainID) }()
+
+	r.mu.Lock()
+	c, err := r.chain(chainID)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return c.channelOutputs(), nil
+}
+
+func (r *Relayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (connections ibc.ConnectionOutputs, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-connections", chainID) }()
+
+	r.mu.Lock()
+	c, err := r.chain(chainID)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return c.connectionOutputs(), nil
+}
+
+func (r *Relayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (clients []ibc.ClientOutput, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-clients", chainID) }()
+
+	r.mu.Lock()
+	c, err := r.chain(chainID)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return c.clientOutputs(), nil
+}
+
+// channelPair is both ends of an open channel on a path.
+type channelPair struct {
+	SrcChannelID, DstChannelID string
+}
+
+// channelPairs returns both ends of every open channel on the path's connection.
+// If channelID is not empty, only the channel with that ID on the path's source chain is returned;
+// otherwise, channels excluded by the path's packet filter are omitted.
+func channelPairs(p path, pathName, channelID string) ([]channelPair, error) {
+	if p.SrcConnectionID == "" {
+		return nil, fmt.Errorf("path %s has no connection; call LinkPath first", pathName)
+	}
+
+	ids := p.Src.channelsOnConnection(p.SrcConnectionID)
+	var pairs []channelPair
+	for _, id := range ids {
+		if channelID != "" && id != channelID {
+			continue
+		}
+		if channelID == "" && !p.PacketFilter.Allows(id) {
+			continue
+		}
+		cpID, open, err := p.Src.channelOnConnection(id, p.SrcConnectionID)
+		if err != nil {
+			return nil, err
+		}
+		if !open {
+			continue
+		}
+		pairs = append(pairs, channelPair{SrcChannelID: id, DstChannelID: cpID})
+	}
+	if channelID != "" && len(pairs) == 0 {
+		return nil, fmt.Errorf("no open channel %s found on path %s", channelID, pathName)
+	}
+	return pairs, nil
+}
+
+// relay relays packets and/or acknowledgements in both directions across the given channels.
+func relay(p path, pairs []channelPair, packets, acks bool) error {
+	for _, pair := range pairs {
+		if packets {
+			if err := relayPackets(p.Src, p.Dst, pair.SrcChannelID); err != nil {
+				return err
+			}
+			if err := relayPackets(p.Dst, p.Src, pair.DstChannelID); err != nil {
+				return err
+			}
+		}
+		if acks {
+			if err := relayAcknowledgements(p.Src, p.Dst, pair.SrcChannelID); err != nil {
+				return err
+			}
+			if err := relayAcknowledgements(p.Dst, p.Src, pair.DstChannelID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// relayPackets delivers the pending packets sent from src on the channel with the given ID to dst,
+// or times them out on src if they can no longer be delivered.
+func relayPackets(src, dst *Chain, channelID string) error {
+	packets, err := src.pendingPackets(channelID)
+	if err != nil {
+		return err
+	}
+	for _, p := range packets {
+		if _, ok := dst.receipt(p); ok {
+			continue
+		}
+		_, err := dst.recvPacket(p)
+		if errors.Is(err, errTimedOut) {
+			err = src.timeoutPacket(p)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to relay packet %d on %s: %w", p.Sequence, channelID, err)
+		}
+	}
+	return nil
+}
+
+// relayAcknowledgements delivers to src the acknowledgements written on dst
+// for the packets sent from src on the channel with the given ID.
+func relayAcknowledgements(src, dst *Chain, channelID string) error {
+	packets, err := src.pendingPackets(channelID)
+	if err != nil {
+		return err
+	}
+	for _, p := range packets {
+		ack, ok := dst.receipt(p)
+		if !ok {
+			continue
+		}
+		if err := src.acknowledgePacket(p, ack); err != nil {
+			return fmt.Errorf("failed to relay acknowledgement of packet %d on %s: %w", p.Sequence, channelID, err)
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "flush-packets", pathName, channelID) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	pairs, err := channelPairs(p, pathName, channelID)
+	if err != nil {
+		return err
+	}
+	return relay(p, pairs, true, false)
+}
+
+func (r *Relayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "flush-acknowledgements", pathName, channelID) }()
+
+	p, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	pairs, err := channelPairs(p, pathName, channelID)
+	if err != nil {
+		return err
+	}
+	return relay(p, pairs, false, true)
+}
+
+// StartRelayer relays on the paths every time one of their chains produces an empty block,
+// until StopRelayer is called.
+func (r *Relayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, append([]string{"start"}, pathNames...)...) }()
+
+	if len(pathNames) == 0 {
+		return errors.New("no paths to relay")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames != nil {
+		return errors.New("relayer is already started")
+	}
+
+	chains := make(map[*Chain]bool)
+	for _, pathName := range pathNames {
+		p, ok := r.paths[pathName]
+		if !ok {
+			return fmt.Errorf("path %s has not been generated", pathName)
+		}
+		chains[p.Src] = true
+		chains[p.Dst] = true
+	}
+
+	r.pathNames = pathNames
+	r.paused = false
+	for c := range chains {
+		r.unsubscribe = append(r.unsubscribe, c.subscribe(r.onBlock))
+	}
+	return nil
+}
+
+// onBlock relays on the started paths, unless the relayer is paused.
+// Failures are retried on the next block.
+func (r *Relayer) onBlock() {
+	// Holding the lock serializes relaying on blocks of different chains,
+	// and ensures that the relayer is not stopped while relaying.
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames == nil || r.paused {
+		return
+	}
+	for _, pathName := range r.pathNames {
+		p := *r.paths[pathName]
+		pairs, err := channelPairs(p, pathName, "")
+		if err != nil {
+			continue
+		}
+		_ = relay(p, pairs, true, true)
+	}
+}
+
+func (r *Relayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "stop") }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames == nil {
+		return errors.New("relayer is not started")
+	}
+	for _, unsubscribe := range r.unsubscribe {
+		unsubscribe()
+	}
+	r.unsubscribe = nil
+	r.pathNames = nil
+	r.paused = false
+	return nil
+}
+
+func (r *Relayer) PauseRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "pause") }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames == nil {
+		return errors.New("relayer is not started")
+	}
+	if r.paused {
+		return errors.New("relayer is already paused")
+	}
+	r.paused = true
+	return nil
+}
+
+func (r *Relayer) ResumeRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "resume") }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames == nil {
+		return errors.New("relayer is not started")
+	}
+	if !r.paused {
+		return errors.New("relayer is not paused")
+	}
+	r.paused = false
+	return nil
+}
+
+// RestartRelayer has no effect on a started relayer,
+// as the mock relayer never leaves work in flight between blocks.
+func (r *Relayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "restart") }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.pathNames == nil {
+		return errors.New("relayer is not started")
+	}
+	r.paused = false
+	return nil
+}
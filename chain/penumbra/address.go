@@ -0,0 +1,151 @@
+package penumbra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Penumbra addresses are encoded with bech32m (BIP-350), rather than the bech32 (BIP-173) used by Cosmos SDK chains.
+// ibctest.User only holds address bytes and encodes them with bech32,
+// so addresses are accepted in either encoding and converted to bech32m before they are passed to pcli.
+
+const (
+	charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+func polymod(values []byte) uint32 {
+	gen := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>i)&1 == 1 {
+				chk ^= gen[i]
+			}
+		}
+	}
+	return chk
+}
+
+func hrpExpand(hrp string) []byte {
+	out := make([]byte, 0, 2*len(hrp)+1)
+	for _, c := range hrp {
+		out = append(out, byte(c>>5))
+	}
+	out = append(out, 0)
+	for _, c := range hrp {
+		out = append(out, byte(c&31))
+	}
+	return out
+}
+
+// convertBits regroups data from groups of fromBits bits into groups of toBits bits.
+// If pad is false, leftover bits must be zero padding, and are discarded.
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var (
+		acc  uint32
+		bits uint
+		out  []byte
+	)
+	maxv := uint32(1)<<toBits - 1
+	for _, b := range data {
+		if uint32(b)>>fromBits != 0 {
+			return nil, fmt.Errorf("invalid data value %d", b)
+		}
+		acc = acc<<fromBits | uint32(b)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			out = append(out, byte(acc>>bits&maxv))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			out = append(out, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, errors.New("invalid padding")
+	}
+	return out, nil
+}
+
+// encodeBech32m encodes addr with the human-readable part hrp using bech32m.
+// Unlike the bech32 encoders of the Cosmos SDK, it does not limit the length of the result, as penumbra addresses exceed 90 characters.
+func encodeBech32m(hrp string, addr []byte) (string, error) {
+	data, err := convertBits(addr, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+
+	values := append(hrpExpand(hrp), data...)
+	values = append(values, 0, 0, 0, 0, 0, 0)
+	mod := polymod(values) ^ bech32mConst
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, d := range data {
+		sb.WriteByte(charset[d])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(charset[(mod>>uint(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// decodeAddress decodes a bech32 or bech32m string of any length,
+// and reports which of the two encodings it uses.
+func decodeAddress(s string) (hrp string, addr []byte, isBech32m bool, err error) {
+	if strings.ToLower(s) != s && strings.ToUpper(s) != s {
+		return "", nil, false, errors.New("mixed case address")
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, false, fmt.Errorf("invalid separator position in address %q", s)
+	}
+	hrp = s[:sep]
+
+	data := make([]byte, 0, len(s)-sep-1)
+	for _, c := range s[sep+1:] {
+		d := strings.IndexRune(charset, c)
+		if d < 0 {
+			return "", nil, false, fmt.Errorf("invalid character %q in address", c)
+		}
+		data = append(data, byte(d))
+	}
+
+	switch polymod(append(hrpExpand(hrp), data...)) {
+	case bech32Const:
+	case bech32mConst:
+		isBech32m = true
+	default:
+		return "", nil, false, fmt.Errorf("invalid checksum in address %q", s)
+	}
+
+	addr, err = convertBits(data[:len(data)-6], 5, 8, false)
+	if err != nil {
+		return "", nil, false, err
+	}
+	return hrp, addr, isBech32m, nil
+}
+
+// toBech32m returns address, which may be encoded with bech32 or bech32m, encoded with bech32m.
+// The human-readable part of a bech32 address is replaced with hrp,
+// as it is typically the chain's Bech32Prefix rather than the prefix of penumbra addresses.
+func toBech32m(address, hrp string) (string, error) {
+	_, addr, isBech32m, err := decodeAddress(address)
+	if err != nil {
+		return "", err
+	}
+	if isBech32m {
+		return address, nil
+	}
+	return encodeBech32m(hrp, addr)
+}
@@ -0,0 +1,51 @@
+package penumbra
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAddress(t *testing.T) {
+	// Test vectors from BIP-173 and BIP-350.
+	hrp, addr, isBech32m, err := decodeAddress("A12UEL5L")
+	require.NoError(t, err)
+	require.Equal(t, "a", hrp)
+	require.Empty(t, addr)
+	require.False(t, isBech32m)
+
+	hrp, addr, isBech32m, err = decodeAddress("a1lqfn3a")
+	require.NoError(t, err)
+	require.Equal(t, "a", hrp)
+	require.Empty(t, addr)
+	require.True(t, isBech32m)
+
+	_, _, _, err = decodeAddress("a1lqfn3b")
+	require.Error(t, err, "invalid checksum must be rejected")
+}
+
+func TestToBech32m(t *testing.T) {
+	// Penumbra addresses are 80 bytes, longer than the Cosmos SDK allows when decoding.
+	raw := bytes.Repeat([]byte{0xab, 0x01}, 40)
+
+	penumbraAddr, err := encodeBech32m("penumbrav1t", raw)
+	require.NoError(t, err)
+
+	// A bech32m address is unchanged.
+	got, err := toBech32m(penumbraAddr, "penumbrav1t")
+	require.NoError(t, err)
+	require.Equal(t, penumbraAddr, got)
+
+	// The bech32 encoding of the same bytes, as produced by ibctest.User, is converted.
+	userAddr := types.MustBech32ifyAddressBytes("penumbra", raw)
+	got, err = toBech32m(userAddr, "penumbrav1t")
+	require.NoError(t, err)
+	require.Equal(t, penumbraAddr, got)
+
+	_, decoded, isBech32m, err := decodeAddress(got)
+	require.NoError(t, err)
+	require.True(t, isBech32m)
+	require.Equal(t, raw, decoded)
+}
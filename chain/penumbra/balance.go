@@ -0,0 +1,71 @@
+package penumbra
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// displayExponent is the number of decimals between a base denom prefixed with "u", such as upenumbra,
+// and the display denom that pcli prints, such as penumbra.
+const displayExponent = 6
+
+// valueRegexp matches a value printed by pcli, such as 1000upenumbra or 1.5penumbra.
+var valueRegexp = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?)([A-Za-z][A-Za-z0-9_/]*)$`)
+
+// parseBalance returns the amount of denom held by the address with the given index,
+// in the output of pcli view balance --by-address.
+// The output is a table with the address index in its first column,
+// followed by one or more rows of values for each address; table borders are ignored.
+func parseBalance(out, index, denom string) (int64, error) {
+	var (
+		total   int64
+		current string
+	)
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.NewReplacer("│", " ", "|", " ", "┆", " ").Replace(line)
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 64); err == nil {
+			current = fields[0]
+			fields = fields[1:]
+		}
+		if current != index {
+			continue
+		}
+
+		for _, f := range fields {
+			m := valueRegexp.FindStringSubmatch(f)
+			if m == nil {
+				continue
+			}
+			amount, err := baseAmount(m[1], m[2], denom)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q: %w", f, err)
+			}
+			total += amount
+		}
+	}
+	return total, nil
+}
+
+// baseAmount returns amount, printed in unit, as an integer amount of denom.
+// It returns zero if unit is not denom or the display unit of denom.
+func baseAmount(amount, unit, denom string) (int64, error) {
+	switch {
+	case unit == denom:
+		return strconv.ParseInt(amount, 10, 64)
+	case "u"+unit == denom:
+		f, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			return 0, err
+		}
+		return int64(math.Round(f * math.Pow10(displayExponent))), nil
+	default:
+		return 0, nil
+	}
+}
@@ -0,0 +1,32 @@
+package penumbra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBalance(t *testing.T) {
+	const out = `
+ Address  Amount
+ 0        1000upenumbra
+          5cube
+ 1        1.5penumbra
+          20upenumbra
+ 2        7upenumbra
+`
+	for _, tt := range []struct {
+		index, denom string
+		want         int64
+	}{
+		{index: "0", denom: "upenumbra", want: 1000},
+		{index: "0", denom: "cube", want: 5},
+		{index: "1", denom: "upenumbra", want: 1_500_020},
+		{index: "2", denom: "upenumbra", want: 7},
+		{index: "3", denom: "upenumbra", want: 0},
+	} {
+		got, err := parseBalance(out, tt.index, tt.denom)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "balance of %s at address %s", tt.denom, tt.index)
+	}
+}
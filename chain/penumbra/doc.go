@@ -1,2 +1,7 @@
 // Package penumbra provides an implementation of ibc.Chain for the Penumbra blockchain.
+//
+// Keys, transfers and balances are handled by running pcli against the chain's nodes.
+// Penumbra balances are shielded, so GetBalance only reports the balances of keys created or recovered on the chain.
+// ICS-20 transfers are sent as pcli withdrawals, whose packets are found in the events of the chain's blocks.
+// pd has no way to export its state, so ExportState returns ErrExportStateNotSupported.
 package penumbra
@@ -0,0 +1,270 @@
+package penumbra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Penumbra transactions carry IBC messages from ibc-go as protobuf Any values inside penumbra's own actions.
+// Rather than depending on penumbra's protobuf definitions,
+// the transactions in a block are scanned for embedded Any values holding ibc-go messages.
+
+// asAny reports the type URL and value of b, if b is a protobuf-encoded Any holding an IBC message.
+func asAny(b []byte) (typeURL string, value []byte, ok bool) {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 || typ != protowire.BytesType {
+			return "", nil, false
+		}
+		b = b[n:]
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return "", nil, false
+		}
+		b = b[n:]
+
+		switch num {
+		case 1:
+			typeURL = string(v)
+		case 2:
+			value = v
+		default:
+			return "", nil, false
+		}
+	}
+	return typeURL, value, strings.HasPrefix(typeURL, "/ibc.")
+}
+
+// rangeAnys calls f for every IBC message embedded as an Any in the protobuf-encoded message b, at any depth.
+// Length-delimited fields that are not Any values are scanned as nested messages;
+// fields that do not parse as messages, such as strings and raw bytes, are skipped.
+func rangeAnys(b []byte, f func(typeURL string, value []byte)) {
+	for len(b) > 0 {
+		_, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+
+		if typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(0, typ, b)
+			if n < 0 {
+				return
+			}
+			b = b[n:]
+			continue
+		}
+
+		v, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return
+		}
+		b = b[n:]
+
+		if typeURL, value, ok := asAny(v); ok {
+			f(typeURL, value)
+			continue
+		}
+		rangeAnys(v, f)
+	}
+}
+
+// rangeBlockIBCMsgs calls f for every IBC message in the transactions of the block at height.
+func rangeBlockIBCMsgs(ctx context.Context, client rpcclient.Client, height uint64, f func(typeURL string, value []byte)) error {
+	h := int64(height)
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	for _, tx := range block.Block.Txs {
+		rangeAnys(tx, f)
+	}
+	return nil
+}
+
+// sentPacketBlocksMax is how many blocks SendIBCTransfer waits for the packet of a transfer to be committed.
+const sentPacketBlocksMax = 10
+
+// SendIBCTransfer implements ibc.Chain.
+// The transfer is identified among the packets sent on channelID by its receiver, amount.Address.
+func (c *PenumbraChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, timeout *ibc.IBCTimeout) (ibc.Tx, error) {
+	n := c.getRelayerNode()
+	height, err := n.TendermintNode.Height(ctx)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	if err := n.PenumbraAppNode.SendIBCTransfer(ctx, channelID, keyName, amount, timeout); err != nil {
+		return ibc.Tx{}, fmt.Errorf("send ibc transfer: %w", err)
+	}
+
+	next := height + 1
+	for i := 0; i < sentPacketBlocksMax; i++ {
+		latest, err := n.TendermintNode.Height(ctx)
+		if err != nil {
+			return ibc.Tx{}, err
+		}
+		for ; next <= latest; next++ {
+			tx, ok, err := findSentPacket(ctx, n.TendermintNode.Client, next, channelID, amount.Address)
+			if err != nil {
+				return ibc.Tx{}, err
+			}
+			if ok {
+				return tx, nil
+			}
+		}
+		if err := test.WaitForBlocks(ctx, 1, n.TendermintNode); err != nil {
+			return ibc.Tx{}, err
+		}
+	}
+	return ibc.Tx{}, fmt.Errorf("packet of transfer to %s on channel %s not found within %d blocks", amount.Address, channelID, sentPacketBlocksMax)
+}
+
+// findSentPacket returns the transaction in the block at height that sent a transfer to receiver on channelID.
+func findSentPacket(ctx context.Context, client rpcclient.Client, height uint64, channelID, receiver string) (ibc.Tx, bool, error) {
+	h := int64(height)
+	block, err := client.Block(ctx, &h)
+	if err != nil {
+		return ibc.Tx{}, false, fmt.Errorf("tendermint rpc get block: %w", err)
+	}
+	results, err := client.BlockResults(ctx, &h)
+	if err != nil {
+		return ibc.Tx{}, false, fmt.Errorf("tendermint rpc get block results: %w", err)
+	}
+	for i, res := range results.TxsResults {
+		packet, ok, err := sentPacket(res.Events, channelID, receiver)
+		if err != nil {
+			return ibc.Tx{}, false, fmt.Errorf("block at height %d: %w", height, err)
+		}
+		if !ok {
+			continue
+		}
+		return ibc.Tx{
+			Height:   height,
+			TxHash:   fmt.Sprintf("%X", block.Block.Txs[i].Hash()),
+			GasSpent: res.GasUsed,
+			Packet:   packet,
+		}, true, nil
+	}
+	return ibc.Tx{}, false, nil
+}
+
+// sentPacket returns the packet of the send_packet event in events that carries a transfer to receiver on channelID.
+func sentPacket(events []abcitypes.Event, channelID, receiver string) (ibc.Packet, bool, error) {
+	for _, event := range events {
+		if event.Type != "send_packet" {
+			continue
+		}
+		attrs := make(map[string]string, len(event.Attributes))
+		for _, attr := range event.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		if attrs["packet_src_channel"] != channelID {
+			continue
+		}
+		var data struct {
+			Receiver string `json:"receiver"`
+		}
+		if err := json.Unmarshal([]byte(attrs["packet_data"]), &data); err != nil || data.Receiver != receiver {
+			// Not an ICS-20 transfer to receiver.
+			continue
+		}
+
+		seq, err := strconv.ParseUint(attrs["packet_sequence"], 10, 64)
+		if err != nil {
+			return ibc.Packet{}, false, fmt.Errorf("invalid packet sequence %q: %w", attrs["packet_sequence"], err)
+		}
+		timeoutNano, err := strconv.ParseUint(attrs["packet_timeout_timestamp"], 10, 64)
+		if err != nil {
+			return ibc.Packet{}, false, fmt.Errorf("invalid packet timestamp timeout %q: %w", attrs["packet_timeout_timestamp"], err)
+		}
+		return ibc.Packet{
+			Sequence:         seq,
+			SourcePort:       attrs["packet_src_port"],
+			SourceChannel:    attrs["packet_src_channel"],
+			DestPort:         attrs["packet_dst_port"],
+			DestChannel:      attrs["packet_dst_channel"],
+			Data:             []byte(attrs["packet_data"]),
+			TimeoutHeight:    attrs["packet_timeout_height"],
+			TimeoutTimestamp: ibc.Nanoseconds(timeoutNano),
+		}, true, nil
+	}
+	return ibc.Packet{}, false, nil
+}
+
+func toIBCPacket(p chantypes.Packet) ibc.Packet {
+	return ibc.Packet{
+		Sequence:         p.Sequence,
+		SourcePort:       p.SourcePort,
+		SourceChannel:    p.SourceChannel,
+		DestPort:         p.DestinationPort,
+		DestChannel:      p.DestinationChannel,
+		Data:             p.Data,
+		TimeoutHeight:    p.TimeoutHeight.String(),
+		TimeoutTimestamp: ibc.Nanoseconds(p.TimeoutTimestamp),
+	}
+}
+
+// Implements Chain interface
+func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
+	var (
+		acks      []ibc.PacketAcknowledgement
+		decodeErr error
+	)
+	err := rangeBlockIBCMsgs(ctx, c.getRelayerNode().TendermintNode.Client, height, func(typeURL string, value []byte) {
+		if typeURL != "/ibc.core.channel.v1.MsgAcknowledgement" || decodeErr != nil {
+			return
+		}
+		var msg chantypes.MsgAcknowledgement
+		if err := msg.Unmarshal(value); err != nil {
+			decodeErr = fmt.Errorf("decode %s: %w", typeURL, err)
+			return
+		}
+		acks = append(acks, ibc.PacketAcknowledgement{
+			Packet:          toIBCPacket(msg.Packet),
+			Acknowledgement: msg.Acknowledgement,
+		})
+	})
+	if err == nil {
+		err = decodeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find acknowledgements at height %d: %w", height, err)
+	}
+	return acks, nil
+}
+
+// Implements Chain interface
+func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
+	var (
+		timeouts  []ibc.PacketTimeout
+		decodeErr error
+	)
+	err := rangeBlockIBCMsgs(ctx, c.getRelayerNode().TendermintNode.Client, height, func(typeURL string, value []byte) {
+		if typeURL != "/ibc.core.channel.v1.MsgTimeout" || decodeErr != nil {
+			return
+		}
+		var msg chantypes.MsgTimeout
+		if err := msg.Unmarshal(value); err != nil {
+			decodeErr = fmt.Errorf("decode %s: %w", typeURL, err)
+			return
+		}
+		timeouts = append(timeouts, ibc.PacketTimeout{Packet: toIBCPacket(msg.Packet)})
+	})
+	if err == nil {
+		err = decodeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find timeouts at height %d: %w", height, err)
+	}
+	return timeouts, nil
+}
@@ -0,0 +1,92 @@
+package penumbra
+
+import (
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+	abcitypes "github.com/tendermint/tendermint/abci/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestRangeAnys(t *testing.T) {
+	msg := &chantypes.MsgAcknowledgement{
+		Packet: chantypes.NewPacket(
+			[]byte("data"), 7,
+			"transfer", "channel-0",
+			"transfer", "channel-1",
+			clienttypes.NewHeight(0, 100), 0,
+		),
+		Acknowledgement: []byte("ack"),
+	}
+	anyMsg, err := codectypes.NewAnyWithValue(msg)
+	require.NoError(t, err)
+	anyBz, err := anyMsg.Marshal()
+	require.NoError(t, err)
+
+	// Nest the Any inside an action inside a transaction, alongside unrelated fields.
+	action := protowire.AppendTag(nil, 1, protowire.BytesType)
+	action = protowire.AppendBytes(action, anyBz)
+	tx := protowire.AppendTag(nil, 1, protowire.VarintType)
+	tx = protowire.AppendVarint(tx, 42)
+	tx = protowire.AppendTag(tx, 2, protowire.BytesType)
+	tx = protowire.AppendBytes(tx, []byte("not a message"))
+	tx = protowire.AppendTag(tx, 3, protowire.BytesType)
+	tx = protowire.AppendBytes(tx, action)
+
+	var found []string
+	rangeAnys(tx, func(typeURL string, value []byte) {
+		found = append(found, typeURL)
+
+		var got chantypes.MsgAcknowledgement
+		require.NoError(t, got.Unmarshal(value))
+		require.Equal(t, msg.Packet, got.Packet)
+		require.Equal(t, "0-100", toIBCPacket(got.Packet).TimeoutHeight)
+	})
+	require.Equal(t, []string{"/ibc.core.channel.v1.MsgAcknowledgement"}, found)
+}
+
+func TestSentPacket(t *testing.T) {
+	event := func(channelID, receiver string, seq string) abcitypes.Event {
+		attrs := map[string]string{
+			"packet_sequence":          seq,
+			"packet_src_port":          "transfer",
+			"packet_src_channel":       channelID,
+			"packet_dst_port":          "transfer",
+			"packet_dst_channel":       "channel-9",
+			"packet_data":              `{"amount":"100","denom":"upenumbra","receiver":"` + receiver + `","sender":"penumbrav1"}`,
+			"packet_timeout_height":    "0-1000",
+			"packet_timeout_timestamp": "0",
+		}
+		e := abcitypes.Event{Type: "send_packet"}
+		for k, v := range attrs {
+			e.Attributes = append(e.Attributes, abcitypes.EventAttribute{Key: []byte(k), Value: []byte(v)})
+		}
+		return e
+	}
+
+	events := []abcitypes.Event{
+		{Type: "message"},
+		event("channel-1", "cosmos1a", "1"),
+		event("channel-0", "cosmos1b", "2"),
+		event("channel-0", "cosmos1a", "3"),
+	}
+
+	packet, ok, err := sentPacket(events, "channel-0", "cosmos1a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.EqualValues(t, 3, packet.Sequence)
+	require.Equal(t, "channel-0", packet.SourceChannel)
+	require.Equal(t, "channel-9", packet.DestChannel)
+	require.Equal(t, "0-1000", packet.TimeoutHeight)
+	require.NoError(t, packet.Validate())
+
+	_, ok, err = sentPacket(events, "channel-2", "cosmos1a")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = sentPacket([]abcitypes.Event{event("channel-0", "cosmos1a", "x")}, "channel-0", "cosmos1a")
+	require.Error(t, err)
+}
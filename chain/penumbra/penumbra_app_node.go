@@ -1,12 +1,14 @@
 package penumbra
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -184,51 +186,212 @@ func (p *PenumbraAppNode) GenerateGenesisFile(
 	return err
 }
 
-func (p *PenumbraAppNode) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
-	cmd := []string{"pcli", "-w", p.WalletPathContainer(), "addr", "list"}
+// walletAddress is an address listed by pcli addr list.
+type walletAddress struct {
+	Index   string
+	Label   string
+	Address string
+}
+
+// RecoveredKeysDir is the directory holding a wallet for each key recovered through RecoverKey.
+func (p *PenumbraAppNode) RecoveredKeysDir() string {
+	return filepath.Join(p.Dir(), "keys")
+}
+
+// recoveredWalletPathContainer returns the path, in the container, of the wallet of a key recovered through RecoverKey.
+func (p *PenumbraAppNode) recoveredWalletPathContainer(keyName string) string {
+	return filepath.Join(p.HomeDir(), "keys", keyName)
+}
+
+// pcli returns a pcli command using the given wallet, that connects to this node when it needs to.
+func (p *PenumbraAppNode) pcli(wallet string, args ...string) []string {
+	return append([]string{"pcli", "-w", wallet, "-n", p.HostName()}, args...)
+}
+
+// addresses returns the addresses of the given wallet.
+func (p *PenumbraAppNode) addresses(ctx context.Context, wallet string) ([]walletAddress, error) {
+	cmd := []string{"pcli", "-w", wallet, "addr", "list"}
 	stdout, _, err := p.Exec(ctx, cmd, nil)
 	if err != nil {
 		return nil, err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
+	var addresses []walletAddress
+	for _, line := range strings.Split(string(stdout), "\n") {
+		fields := strings.Fields(line)
 		if len(fields) < 3 {
 			continue
 		}
-		if fields[1] == keyName {
-			// TODO penumbra address is bech32m. need to decode to bytes here
-			return []byte(fields[2]), nil
+		if _, err := strconv.ParseUint(fields[0], 10, 64); err != nil {
+			// Header or table border.
+			continue
 		}
+		addresses = append(addresses, walletAddress{Index: fields[0], Label: fields[1], Address: fields[len(fields)-1]})
 	}
-	return []byte{}, errors.New("address not found")
+	return addresses, nil
 }
 
-func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string) (string, error) {
-	cmd := []string{"pcli", "-w", p.WalletPathContainer(), "addr", "list"}
-	stdout, _, err := p.Exec(ctx, cmd, nil)
+// keyAddress returns the wallet and the address of the key named keyName.
+// A key recovered through RecoverKey is the first address of a wallet of its own;
+// any other key is an address labelled keyName in the node's wallet.
+func (p *PenumbraAppNode) keyAddress(ctx context.Context, keyName string) (wallet string, addr walletAddress, err error) {
+	if _, err := os.Stat(filepath.Join(p.RecoveredKeysDir(), keyName)); err == nil {
+		wallet = p.recoveredWalletPathContainer(keyName)
+		addresses, err := p.addresses(ctx, wallet)
+		if err != nil {
+			return "", walletAddress{}, err
+		}
+		if len(addresses) == 0 {
+			return "", walletAddress{}, fmt.Errorf("wallet of key %s has no address", keyName)
+		}
+		return wallet, addresses[0], nil
+	}
+
+	wallet = p.WalletPathContainer()
+	addresses, err := p.addresses(ctx, wallet)
 	if err != nil {
-		return "", err
+		return "", walletAddress{}, err
 	}
-	addresses := strings.Split(string(stdout), "\n")
-	for _, address := range addresses {
-		fields := strings.Fields(address)
-		if len(fields) < 3 {
-			continue
+	for _, a := range addresses {
+		if a.Label == keyName {
+			return wallet, a, nil
 		}
-		if fields[1] == keyName {
-			return fields[2], nil
+	}
+	return "", walletAddress{}, errors.New("address not found")
+}
+
+// walletOf returns the wallet and the address, among the node's wallet and the wallets of recovered keys,
+// whose bytes match address.
+// Penumbra balances are shielded, so they can only be viewed through the wallet holding the address.
+func (p *PenumbraAppNode) walletOf(ctx context.Context, address string) (wallet string, addr walletAddress, err error) {
+	_, want, _, err := decodeAddress(address)
+	if err != nil {
+		return "", walletAddress{}, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	wallets := []string{p.WalletPathContainer()}
+	entries, err := os.ReadDir(p.RecoveredKeysDir())
+	if err != nil && !os.IsNotExist(err) {
+		return "", walletAddress{}, err
+	}
+	for _, e := range entries {
+		wallets = append(wallets, p.recoveredWalletPathContainer(e.Name()))
+	}
+
+	for _, wallet := range wallets {
+		addresses, err := p.addresses(ctx, wallet)
+		if err != nil {
+			return "", walletAddress{}, err
+		}
+		for _, a := range addresses {
+			_, got, _, err := decodeAddress(a.Address)
+			if err == nil && bytes.Equal(got, want) {
+				return wallet, a, nil
+			}
 		}
 	}
-	return "", errors.New("address not found")
+	return "", walletAddress{}, fmt.Errorf("address %s does not belong to a wallet on %s", address, p.Name())
 }
 
+// RecoverKey imports the wallet derived from mnemonic as the key named name.
+func (p *PenumbraAppNode) RecoverKey(ctx context.Context, name, mnemonic string) error {
+	if err := os.MkdirAll(p.RecoveredKeysDir(), 0755); err != nil {
+		return err
+	}
+	cmd := []string{"pcli", "-w", p.recoveredWalletPathContainer(name), "wallet", "import-from-phrase", mnemonic}
+	_, _, err := p.Exec(ctx, cmd, nil)
+	return err
+}
+
+// GetAddress returns the bytes of the bech32m address of the key named keyName.
+func (p *PenumbraAppNode) GetAddress(ctx context.Context, keyName string) ([]byte, error) {
+	_, addr, err := p.keyAddress(ctx, keyName)
+	if err != nil {
+		return nil, err
+	}
+	_, bz, _, err := decodeAddress(addr.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address of key %s: %w", keyName, err)
+	}
+	return bz, nil
+}
+
+func (p *PenumbraAppNode) GetAddressBech32m(ctx context.Context, keyName string) (string, error) {
+	_, addr, err := p.keyAddress(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+	return addr.Address, nil
+}
+
+// SendFunds sends amount from the key named keyName.
+// The recipient may be encoded with bech32, as by ibctest.User, or bech32m.
 func (p *PenumbraAppNode) SendFunds(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
-	return errors.New("not yet implemented")
+	wallet, from, err := p.keyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	hrp, _, _, err := decodeAddress(from.Address)
+	if err != nil {
+		return fmt.Errorf("invalid address of key %s: %w", keyName, err)
+	}
+	to, err := toBech32m(amount.Address, hrp)
+	if err != nil {
+		return fmt.Errorf("invalid recipient address %s: %w", amount.Address, err)
+	}
+
+	cmd := p.pcli(wallet,
+		"tx", "send", fmt.Sprintf("%d%s", amount.Amount, amount.Denom),
+		"--to", to,
+		"--from", from.Index,
+	)
+	_, _, err = p.Exec(ctx, cmd, nil)
+	return err
+}
+
+// GetBalance returns the balance of denom held by address,
+// which must belong to the node's wallet or to a key recovered on the node.
+func (p *PenumbraAppNode) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
+	wallet, addr, err := p.walletOf(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	stdout, _, err := p.Exec(ctx, p.pcli(wallet, "view", "balance", "--by-address"), nil)
+	if err != nil {
+		return 0, err
+	}
+	return parseBalance(string(stdout), addr.Index, denom)
 }
 
-func (p *PenumbraAppNode) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, timeout *ibc.IBCTimeout) (ibc.Tx, error) {
-	return ibc.Tx{}, errors.New("not yet implemented")
+// SendIBCTransfer withdraws amount from the key named keyName, as an ICS-20 transfer over channelID,
+// to amount.Address on the counterparty chain.
+// pcli does not report the packet it sends, so PenumbraChain.SendIBCTransfer finds it in the chain's blocks.
+func (p *PenumbraAppNode) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, timeout *ibc.IBCTimeout) error {
+	wallet, from, err := p.keyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	// pcli identifies channels by their number.
+	channel, err := strconv.ParseUint(strings.TrimPrefix(channelID, "channel-"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid channel %s: %w", channelID, err)
+	}
+
+	cmd := p.pcli(wallet,
+		"tx", "withdraw",
+		"--to", amount.Address,
+		"--channel", strconv.FormatUint(channel, 10),
+		"--source", from.Index,
+	)
+	if timeout != nil {
+		if timeout.NanoSeconds > 0 {
+			cmd = append(cmd, "--timeout-timestamp", fmt.Sprint(timeout.NanoSeconds))
+		} else if timeout.Height > 0 {
+			cmd = append(cmd, "--timeout-height", fmt.Sprintf("0-%d", timeout.Height))
+		}
+	}
+	cmd = append(cmd, fmt.Sprintf("%d%s", amount.Amount, amount.Denom))
+	_, _, err = p.Exec(ctx, cmd, nil)
+	return err
 }
 
 func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context) error {
@@ -88,14 +88,6 @@ func NewPenumbraChain(log *zap.Logger, testName string, chainConfig ibc.ChainCon
 	}
 }
 
-func (c *PenumbraChain) Acknowledgements(ctx context.Context, height uint64) ([]ibc.PacketAcknowledgement, error) {
-	panic("implement me")
-}
-
-func (c *PenumbraChain) Timeouts(ctx context.Context, height uint64) ([]ibc.PacketTimeout, error) {
-	panic("implement me")
-}
-
 // Implements Chain interface
 func (c *PenumbraChain) Config() ibc.ChainConfig {
 	return c.cfg
@@ -134,7 +126,7 @@ func (c *PenumbraChain) GetGRPCAddress() string {
 // GetHostRPCAddress returns the address of the RPC server accessible by the host.
 // This will not return a valid address until the chain has been started.
 func (c *PenumbraChain) GetHostRPCAddress() string {
-	return "http://" + c.getRelayerNode().PenumbraAppNode.hostRPCPort
+	return "http://" + c.getRelayerNode().TendermintNode.HostRPCPort()
 }
 
 // GetHostGRPCAddress returns the address of the gRPC server accessible by the host.
@@ -143,8 +135,9 @@ func (c *PenumbraChain) GetHostGRPCAddress() string {
 	return c.getRelayerNode().PenumbraAppNode.hostGRPCPort
 }
 
+// HomeDir returns the home directory of the penumbra app node used for keys and transactions.
 func (c *PenumbraChain) HomeDir() string {
-	panic(errors.New("HomeDir not implemented yet"))
+	return c.getRelayerNode().PenumbraAppNode.HomeDir()
 }
 
 // Implements Chain interface
@@ -152,8 +145,9 @@ func (c *PenumbraChain) CreateKey(ctx context.Context, keyName string) error {
 	return c.getRelayerNode().PenumbraAppNode.CreateKey(ctx, keyName)
 }
 
+// Implements Chain interface
 func (c *PenumbraChain) RecoverKey(ctx context.Context, name, mnemonic string) error {
-	return fmt.Errorf("RecoverKey not implemented for PenumbraChain")
+	return c.getRelayerNode().PenumbraAppNode.RecoverKey(ctx, name, mnemonic)
 }
 
 // Implements Chain interface
@@ -166,11 +160,6 @@ func (c *PenumbraChain) SendFunds(ctx context.Context, keyName string, amount ib
 	return c.getRelayerNode().PenumbraAppNode.SendFunds(ctx, keyName, amount)
 }
 
-// Implements Chain interface
-func (c *PenumbraChain) SendIBCTransfer(ctx context.Context, channelID, keyName string, amount ibc.WalletAmount, timeout *ibc.IBCTimeout) (ibc.Tx, error) {
-	return c.getRelayerNode().PenumbraAppNode.SendIBCTransfer(ctx, channelID, keyName, amount, timeout)
-}
-
 // Implements Chain interface
 func (c *PenumbraChain) InstantiateContract(ctx context.Context, keyName string, amount ibc.WalletAmount, fileName, initMessage string, needsNoAdminFlag bool) (string, error) {
 	// NOOP
@@ -189,9 +178,17 @@ func (c *PenumbraChain) DumpContractState(ctx context.Context, contractAddress s
 	return nil, errors.New("not yet implemented")
 }
 
-// Implements Chain interface
+// ErrExportStateNotSupported is returned by ExportState.
+//
+// Exporting state is out of scope for Penumbra chains:
+// a cosmos chain exports its application state as a genesis document that a new chain can start from,
+// but pd keeps its state in a Jellyfish Merkle tree that has no genesis representation,
+// and neither pd nor pcli provides a command to dump it at a given height.
+var ErrExportStateNotSupported = errors.New("penumbra does not support exporting state")
+
+// ExportState always returns ErrExportStateNotSupported.
 func (c *PenumbraChain) ExportState(ctx context.Context, height int64) (string, error) {
-	return "", errors.New("not yet implemented")
+	return "", ErrExportStateNotSupported
 }
 
 // Implements Chain interface
@@ -200,13 +197,15 @@ func (c *PenumbraChain) CreatePool(ctx context.Context, keyName string, contract
 	return errors.New("not yet implemented")
 }
 
+// Implements Chain interface
 func (c *PenumbraChain) Height(ctx context.Context) (uint64, error) {
 	return c.getRelayerNode().TendermintNode.Height(ctx)
 }
 
-// Implements Chain interface
+// GetBalance implements Chain interface.
+// Penumbra balances are shielded, so address must belong to a key created or recovered on the chain.
 func (c *PenumbraChain) GetBalance(ctx context.Context, address string, denom string) (int64, error) {
-	return -1, errors.New("not yet implemented")
+	return c.getRelayerNode().PenumbraAppNode.GetBalance(ctx, address, denom)
 }
 
 // Implements Chain interface
@@ -338,14 +337,6 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 		})
 	}
 
-	for _, wallet := range additionalGenesisWallets {
-		allocations = append(allocations, PenumbraGenesisAppStateAllocation{
-			Address: wallet.Address,
-			Denom:   wallet.Denom,
-			Amount:  wallet.Amount,
-		})
-	}
-
 	for _, n := range fullnodes {
 		n := n
 		eg.Go(func() error { return n.TendermintNode.InitFullNodeFiles(ctx) })
@@ -355,6 +346,24 @@ func (c *PenumbraChain) Start(testName string, ctx context.Context, additionalGe
 		return fmt.Errorf("waiting to init full nodes' files: %w", err)
 	}
 
+	// Genesis wallets, such as the faucet, may be encoded with bech32 rather than bech32m.
+	// Use the prefix of the validators' addresses for them.
+	hrp, _, _, err := decodeAddress(validatorDefinitions[0].FundingStreams[0].Address)
+	if err != nil {
+		return fmt.Errorf("invalid validator address: %w", err)
+	}
+	for _, wallet := range additionalGenesisWallets {
+		address, err := toBech32m(wallet.Address, hrp)
+		if err != nil {
+			return fmt.Errorf("invalid genesis wallet address %s: %w", wallet.Address, err)
+		}
+		allocations = append(allocations, PenumbraGenesisAppStateAllocation{
+			Address: address,
+			Denom:   wallet.Denom,
+			Amount:  wallet.Amount,
+		})
+	}
+
 	firstValidator := validators[0]
 	if err := firstValidator.PenumbraAppNode.GenerateGenesisFile(ctx, chainCfg.ChainID, validatorDefinitions, allocations); err != nil {
 		return fmt.Errorf("generating genesis file: %w", err)
@@ -429,6 +438,77 @@ func (c *PenumbraChain) start(testName string, ctx context.Context, genesisFileP
 	return err
 }
 
+// node returns the node at index i of PenumbraNodes.
+func (c *PenumbraChain) node(i int) (PenumbraNode, error) {
+	if i < 0 || i >= len(c.PenumbraNodes) {
+		return PenumbraNode{}, fmt.Errorf("node index %d out of range of %d nodes", i, len(c.PenumbraNodes))
+	}
+	return c.PenumbraNodes[i], nil
+}
+
+// StopNode stops the tendermint and penumbra containers of the node at index i of PenumbraNodes.
+// The node's state is kept, so the node can be started again with StartNode.
+func (c *PenumbraChain) StopNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Stopping tendermint container", zap.String("container", n.TendermintNode.Name()))
+	if err := n.TendermintNode.StopContainer(ctx); err != nil {
+		return err
+	}
+	c.log.Info("Stopping penumbra container", zap.String("container", n.PenumbraAppNode.Name()))
+	return n.PenumbraAppNode.StopContainer(ctx)
+}
+
+// StartNode starts the stopped containers of the node at index i of PenumbraNodes,
+// reconnecting its RPC client to the node's new host ports, and waits for the node to catch up.
+// The penumbra container is started first, as tendermint connects to it on start.
+func (c *PenumbraChain) StartNode(ctx context.Context, i int) error {
+	n, err := c.node(i)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Starting penumbra container", zap.String("container", n.PenumbraAppNode.Name()))
+	if err := n.PenumbraAppNode.StartContainer(ctx); err != nil {
+		return err
+	}
+	c.log.Info("Starting tendermint container", zap.String("container", n.TendermintNode.Name()))
+	return n.TendermintNode.StartContainer(ctx)
+}
+
+// RestartNode stops and then starts the node at index i of PenumbraNodes.
+func (c *PenumbraChain) RestartNode(ctx context.Context, i int) error {
+	if err := c.StopNode(ctx, i); err != nil {
+		return err
+	}
+	return c.StartNode(ctx, i)
+}
+
+// StopAllNodes stops the containers of every node, halting the chain.
+func (c *PenumbraChain) StopAllNodes(ctx context.Context) error {
+	var eg errgroup.Group
+	for i := range c.PenumbraNodes {
+		i := i
+		eg.Go(func() error {
+			return c.StopNode(ctx, i)
+		})
+	}
+	return eg.Wait()
+}
+
+// StartAllNodes starts the stopped containers of every node, and waits for the nodes to catch up.
+func (c *PenumbraChain) StartAllNodes(ctx context.Context) error {
+	eg, egCtx := errgroup.WithContext(ctx)
+	for i := range c.PenumbraNodes {
+		i := i
+		eg.Go(func() error {
+			return c.StartNode(egCtx, i)
+		})
+	}
+	return eg.Wait()
+}
+
 func (c *PenumbraChain) Cleanup(ctx context.Context) error {
 	var eg errgroup.Group
 	for _, p := range c.PenumbraNodes {
@@ -444,8 +524,7 @@ func (c *PenumbraChain) Cleanup(ctx context.Context) error {
 }
 
 func (c *PenumbraChain) RegisterInterchainAccount(ctx context.Context, keyName, connectionID string) (string, error) {
-	//TODO implement me
-	panic("implement me")
+	return "", ibc.ErrInterchainAccountsNotSupported
 }
 
 func (c *PenumbraChain) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/penumbra"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/test"
 	"github.com/stretchr/testify/require"
@@ -55,3 +56,9 @@ func TestPenumbraChainStart(t *testing.T) {
 
 	require.NoError(t, err, "penumbra chain failed to make blocks")
 }
+
+func TestPenumbraChain_ExportState(t *testing.T) {
+	var c penumbra.PenumbraChain
+	_, err := c.ExportState(context.Background(), 1)
+	require.ErrorIs(t, err, penumbra.ErrExportStateNotSupported)
+}
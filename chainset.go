@@ -27,9 +27,12 @@ type chainSet struct {
 	chains map[ibc.Chain]struct{}
 
 	// The following fields are set during TrackBlocks, and used in Close.
-	trackerEg  *errgroup.Group
-	db         *sql.DB
-	collectors []*blockdb.Collector
+	trackerEg     *errgroup.Group
+	trackerCancel context.CancelFunc
+	db            *sql.DB
+
+	// Set during TrackBlocks, so that other data can be attached to the same test case.
+	testCase *blockdb.TestCase
 }
 
 func newChainSet(log *zap.Logger, chains []ibc.Chain) *chainSet {
@@ -135,7 +138,7 @@ func (cs *chainSet) Start(ctx context.Context, testName string, additionalGenesi
 // The gitSha is used to pin a git commit to a test invocation. Thus, when a user is looking at historical
 // data they are able to determine which version of the code produced the results.
 // Expected to be called after Start.
-func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
+func (cs *chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha string) error {
 	if len(dbPath) == 0 {
 		// nop
 		return nil
@@ -158,13 +161,16 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 	testCase, err := blockdb.CreateTestCase(ctx, db, testName, gitSha)
 	if err != nil {
 		_ = db.Close()
+		cs.db = nil
 		return fmt.Errorf("create test case in sqlite database: %w", err)
 	}
+	cs.testCase = testCase
+
+	// The collectors run until Close is called.
+	ctx, cs.trackerCancel = context.WithCancel(ctx)
 
 	// TODO (nix - 6/1/22) Need logger instead of fmt.Fprint
 	cs.trackerEg = new(errgroup.Group)
-	cs.collectors = make([]*blockdb.Collector, len(cs.chains))
-	i := 0
 	for c := range cs.chains {
 		c := c
 		id := c.Config().ChainID
@@ -173,7 +179,6 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 			fmt.Fprintf(os.Stderr, `Chain %s is not configured to save blocks; must implement "FindTxs(ctx context.Context, height uint64) ([][]byte, error)"`+"\n", id)
 			return nil
 		}
-		j := i // Avoid closure on loop variable.
 		cs.trackerEg.Go(func() error {
 			chaindb, err := testCase.AddChain(ctx, id, c.Config().Type)
 			if err != nil {
@@ -182,11 +187,9 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 			}
 			log := cs.log.With(zap.String("chain_id", id))
 			collector := blockdb.NewCollector(log, finder, chaindb, 100*time.Millisecond)
-			cs.collectors[j] = collector
 			collector.Collect(ctx)
 			return nil
 		})
-		i++
 	}
 
 	return nil
@@ -197,10 +200,8 @@ func (cs chainSet) TrackBlocks(ctx context.Context, testName, dbPath, gitSha str
 // Currently, it only frees resources from TrackBlocks.
 // Close is safe to call even if TrackBlocks was not called.
 func (cs *chainSet) Close() error {
-	for _, c := range cs.collectors {
-		if c != nil {
-			c.Stop()
-		}
+	if cs.trackerCancel != nil {
+		cs.trackerCancel()
 	}
 
 	var err error
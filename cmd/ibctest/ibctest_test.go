@@ -20,6 +20,7 @@ import (
 	blockdbtui "github.com/strangelove-ventures/ibctest/internal/blockdb/tui"
 	"github.com/strangelove-ventures/ibctest/internal/version"
 	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -44,6 +45,10 @@ func init() {
 var testMatrix struct {
 	Relayers []string
 
+	// RelayerUpgrades maps a relayer name in Relayers to the image its relayers are upgraded to
+	// in the relayer upgrade test, which only runs for the relayers listed here.
+	RelayerUpgrades map[string]ibc.DockerImage
+
 	ChainSets [][]*ibctest.ChainSpec
 }
 
@@ -129,10 +134,17 @@ func setUpTestMatrix() error {
 
 func validateTestMatrix() error {
 	nop := zap.NewNop()
-	for _, r := range testMatrix.Relayers {
-		if _, err := getRelayerFactory(r, nop); err != nil {
+	relayerFactories := make([]ibctest.RelayerFactory, len(testMatrix.Relayers))
+	for i, r := range testMatrix.Relayers {
+		rf, err := getRelayerFactory(r, nop)
+		if err != nil {
 			return err
 		}
+		relayerFactories[i] = rf
+	}
+
+	if _, err := relayerUpgradeOptions(testMatrix.Relayers, relayerFactories, testMatrix.RelayerUpgrades); err != nil {
+		return err
 	}
 
 	for _, cs := range testMatrix.ChainSets {
@@ -174,8 +186,10 @@ func getRelayerFactory(name string, logger *zap.Logger) (ibctest.RelayerFactory,
 		return ibctest.NewBuiltinRelayerFactory(ibc.CosmosRly, logger), nil
 	case "hermes":
 		return ibctest.NewBuiltinRelayerFactory(ibc.Hermes, logger), nil
+	case "inprocess":
+		return ibctest.NewBuiltinRelayerFactory(ibc.InProcess, logger), nil
 	default:
-		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes)", name)
+		return nil, fmt.Errorf("unknown relayer type %q (valid types: rly, hermes, inprocess)", name)
 	}
 }
 
@@ -186,6 +200,32 @@ func getChainFactory(log *zap.Logger, chainSpecs []*ibctest.ChainSpec) (ibctest.
 	return ibctest.NewBuiltinChainFactory(log, chainSpecs), nil
 }
 
+// relayerUpgradeOptions returns the conformance options that upgrade the relayers built by rfs,
+// which were built from the relayer names in relayers, to the images in upgrades.
+func relayerUpgradeOptions(relayers []string, rfs []ibctest.RelayerFactory, upgrades map[string]ibc.DockerImage) ([]conformance.TestOption, error) {
+	var opts []conformance.TestOption
+	for name, newImage := range upgrades {
+		i := indexOf(relayers, name)
+		if i < 0 {
+			return nil, fmt.Errorf("relayer upgrade for %q, which is not in Relayers", name)
+		}
+		if newImage.Repository == "" || newImage.Version == "" {
+			return nil, fmt.Errorf("relayer upgrade for %q must set both Repository and Version", name)
+		}
+		opts = append(opts, conformance.RelayerUpgrade(rfs[i].Name(), newImage))
+	}
+	return opts, nil
+}
+
+func indexOf(ss []string, s string) int {
+	for i := range ss {
+		if ss[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
 // TestConformance is the root test for the ibc conformance tests.
 // It runs many subtests in parallel;
 // if this is too taxing on a system, the -test.parallel flag
@@ -225,8 +265,37 @@ func TestConformance(t *testing.T) {
 		relayerFactories[i] = rf
 	}
 
+	opts, err := relayerUpgradeOptions(testMatrix.Relayers, relayerFactories, testMatrix.RelayerUpgrades)
+	if err != nil {
+		// This error should have been validated before running tests.
+		panic(err)
+	}
+
 	// Begin test execution, which will spawn many parallel subtests.
-	conformance.Test(t, chainFactories, relayerFactories, reporter)
+	conformance.Test(t, chainFactories, relayerFactories, reporter, opts...)
+}
+
+func TestMatrixRelayerUpgrades(t *testing.T) {
+	nop := zap.NewNop()
+	relayers := []string{"rly", "hermes"}
+	rfs := make([]ibctest.RelayerFactory, len(relayers))
+	for i, r := range relayers {
+		rf, err := getRelayerFactory(r, nop)
+		require.NoError(t, err)
+		rfs[i] = rf
+	}
+
+	newImage := ibc.DockerImage{Repository: "ghcr.io/cosmos/relayer", Version: "main"}
+
+	opts, err := relayerUpgradeOptions(relayers, rfs, map[string]ibc.DockerImage{"rly": newImage})
+	require.NoError(t, err)
+	require.Equal(t, []conformance.TestOption{conformance.RelayerUpgrade(rfs[0].Name(), newImage)}, opts)
+
+	_, err = relayerUpgradeOptions(relayers, rfs, map[string]ibc.DockerImage{"inprocess": newImage})
+	require.ErrorContains(t, err, "not in Relayers")
+
+	_, err = relayerUpgradeOptions(relayers, rfs, map[string]ibc.DockerImage{"rly": {Repository: "ghcr.io/cosmos/relayer"}})
+	require.ErrorContains(t, err, "Repository and Version")
 }
 
 // addFlags configures additional flags beyond the default testing flags.
@@ -0,0 +1,53 @@
+package conformance
+
+import (
+	"context"
+
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// The helpers in this file check what the relayer reports against the IBC state of the chains themselves,
+// for chains that implement ibc.IBCQueryingChain. They check nothing for other chains.
+
+// requireChainConnection asserts that c has the open connection conn, as reported by the relayer.
+func requireChainConnection(ctx context.Context, req *require.Assertions, c ibc.Chain, conn *ibc.ConnectionOutput) {
+	q, ok := c.(ibc.IBCQueryingChain)
+	if !ok {
+		return
+	}
+	end, err := q.QueryConnection(ctx, conn.ID)
+	req.NoError(err, "failed to query connection %s on %s", conn.ID, c.Config().ChainID)
+	req.Equal(conntypes.OPEN, end.State)
+	req.Equal(conn.ClientID, end.ClientId)
+	req.Equal(conn.Counterparty.ClientId, end.Counterparty.ClientId)
+	req.Equal(conn.Counterparty.ConnectionId, end.Counterparty.ConnectionId)
+}
+
+// requireChainChannel asserts that c has the open channel ch, as reported by the relayer.
+func requireChainChannel(ctx context.Context, req *require.Assertions, c ibc.Chain, ch ibc.ChannelOutput) {
+	q, ok := c.(ibc.IBCQueryingChain)
+	if !ok {
+		return
+	}
+	end, err := q.QueryChannel(ctx, ch.PortID, ch.ChannelID)
+	req.NoError(err, "failed to query channel %s/%s on %s", ch.PortID, ch.ChannelID, c.Config().ChainID)
+	req.Equal(chantypes.OPEN, end.State)
+	req.Equal(ch.Counterparty.PortID, end.Counterparty.PortId)
+	req.Equal(ch.Counterparty.ChannelID, end.Counterparty.ChannelId)
+	req.Equal(ch.ConnectionHops, end.ConnectionHops)
+	req.Equal(ch.Version, end.Version)
+}
+
+// requireNoPacketCommitments asserts that every packet sent by c on the channel has been acknowledged or timed out.
+func requireNoPacketCommitments(ctx context.Context, req *require.Assertions, c ibc.Chain, portID, channelID string) {
+	q, ok := c.(ibc.IBCQueryingChain)
+	if !ok {
+		return
+	}
+	sequences, err := q.QueryPacketCommitments(ctx, portID, channelID)
+	req.NoError(err, "failed to query packet commitments on %s", c.Config().ChainID)
+	req.Empty(sequences, "packets sent on channel %s/%s of %s are still pending", portID, channelID, c.Config().ChainID)
+}
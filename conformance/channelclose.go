@@ -0,0 +1,163 @@
+package conformance
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// icaPacketTimeout is how long to wait for a packet sent over an interchain account channel to time out.
+// The interchain accounts demo chain sends its packets with a one minute timeout.
+const icaPacketTimeout = 75 * time.Second
+
+// TestRelayerChannelClose asserts that both ends of a channel reach STATE_CLOSED
+// when an ordered channel is closed by a packet timeout, and the relayer closes the counterparty.
+//
+// The built-in applications reject user-initiated channel closes,
+// so closing an ordered interchain accounts channel through a timeout is the only way to exercise the close handshake.
+// This test is skipped if the first chain cannot register an interchain account.
+func TestRelayerChannelClose(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.OrderedChannels, relayer.ChannelClose)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network, home)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	connectionID := channels[0].ConnectionHops[0]
+
+	c0FaucetAddrBytes, err := c0.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+	req.NoError(err)
+	c0FaucetAddr, err := types.Bech32ifyAddressBytes(c0.Config().Bech32Prefix, c0FaucetAddrBytes)
+	req.NoError(err)
+
+	_, err = c0.RegisterInterchainAccount(ctx, ibctest.FaucetAccountKeyName, connectionID)
+	if errors.Is(err, ibc.ErrInterchainAccountsNotSupported) {
+		rep.TrackSkip(t, "skipping because %s does not support interchain accounts: %v", c0.Config().ChainID, err)
+	}
+	req.NoError(err, "failed to register an interchain account on %s", c0.Config().ChainID)
+
+	// The relayer completes the ordered channel handshake started by the registration.
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	ordered, err := pollForOrderedChannel(ctx, r, eRep, c0, c1, connectionID)
+	req.NoError(err)
+	icaAddr, err := c0.QueryInterchainAccount(ctx, connectionID, c0FaucetAddr)
+	req.NoError(err)
+	req.NoError(r.StopRelayer(ctx, eRep))
+
+	// Send a packet over the ordered channel while the relayer is stopped, so that it times out.
+	req.NoError(c0.SendICABankTransfer(ctx, connectionID, c0FaucetAddr, ibc.WalletAmount{
+		Address: icaAddr,
+		Denom:   c1.Config().Denom,
+		Amount:  1,
+	}))
+	time.Sleep(icaPacketTimeout)
+
+	// Relaying the timeout closes the ordered channel on the first chain.
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	req.NoError(pollForChannelState(ctx, r, eRep, c0, c1, ordered.ChannelID, chantypes.CLOSED))
+	req.NoError(r.StopRelayer(ctx, eRep))
+
+	t.Run("close counterparty", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		c1Channels, err := r.GetChannels(ctx, eRep, c1.Config().ChainID)
+		req.NoError(err)
+		counterparty, ok := findChannel(c1Channels, ordered.Counterparty.ChannelID)
+		req.True(ok, "counterparty of ordered channel %s not found", ordered.ChannelID)
+
+		// The relayer may have already closed the counterparty while it was running.
+		if counterparty.State != chantypes.CLOSED.String() {
+			req.NoError(r.CloseChannel(ctx, eRep, pathName, ordered.ChannelID))
+		}
+
+		req.NoError(pollForChannelState(ctx, r, eRep, c1, c0, ordered.Counterparty.ChannelID, chantypes.CLOSED))
+
+		c0Channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+		req.NoError(err)
+		closed, ok := findChannel(c0Channels, ordered.ChannelID)
+		req.True(ok)
+		req.Equal(chantypes.CLOSED.String(), closed.State)
+	})
+}
+
+// pollForChannelState waits for the channel with the given ID on chain to reach the given state,
+// as reported by r.
+func pollForChannelState(
+	ctx context.Context,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	chain, counterpartyChain ibc.Chain,
+	channelID string,
+	state chantypes.State,
+) error {
+	chainID := chain.Config().ChainID
+	for i := uint64(0); i < pollHeightMax; i++ {
+		if err := test.WaitForBlocks(ctx, 1, chain, counterpartyChain); err != nil {
+			return fmt.Errorf("failed to wait for blocks: %w", err)
+		}
+
+		channels, err := r.GetChannels(ctx, rep, chainID)
+		if err != nil {
+			return fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+		}
+		if c, ok := findChannel(channels, channelID); ok && c.State == state.String() {
+			return nil
+		}
+	}
+	return fmt.Errorf("channel %s on %s did not reach %s", channelID, chainID, state)
+}
@@ -0,0 +1,117 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// connectionDelay is the delay period of the connection created by TestRelayerConnectionDelay.
+// It is long enough that a packet relayed without waiting for it would be noticed.
+const connectionDelay = 20 * time.Second
+
+// TestRelayerConnectionDelay links a path whose connection has a delay period,
+// and asserts that the relayer creates the connection with that delay
+// and relays a packet over it only once the delay has passed.
+//
+// The delay applies to every packet on the connection,
+// so this test runs on chains of its own rather than as one of the cases of TestChainPair.
+func TestRelayerConnectionDelay(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.ConnectionDelay)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network, home)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+
+		// The path is generated below, with a connection delay.
+		SkipPathCreation: true,
+	}))
+	defer ic.Close()
+
+	req.NoError(r.GeneratePath(ctx, eRep, c0.Config().ChainID, c1.Config().ChainID, pathName, ibc.PathOptions{
+		ConnectionDelay: connectionDelay,
+	}))
+	req.NoError(r.LinkPath(ctx, eRep, pathName, ibc.DefaultChannelOpts()))
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	// The delay period is part of the connection end, so check it on the chain when possible.
+	if q, ok := c0.(ibc.IBCQueryingChain); ok {
+		req.Len(channel.ConnectionHops, 1)
+		conn, err := q.QueryConnection(ctx, channel.ConnectionHops[0])
+		req.NoError(err)
+		req.Equal(uint64(connectionDelay), conn.DelayPeriod, "connection %s has the wrong delay period", channel.ConnectionHops[0])
+	}
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "delay", userFaucetFund, c0)[0]
+	sentAt := time.Now()
+	tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+		Address: user.Bech32Address(c1.Config().Bech32Prefix),
+		Denom:   c0.Config().Denom,
+		Amount:  testCoinAmount,
+	}, nil)
+	req.NoError(err)
+	req.NoError(tx.Validate())
+
+	ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+	req.NoError(err, "failed to get acknowledgement on channel %s", channel.ChannelID)
+	req.NoError(ack.Validate())
+
+	// The packet can only be received once the delay has passed
+	// since the receiving chain's client was updated past the packet's height,
+	// which happened after the transfer was submitted.
+	req.GreaterOrEqual(time.Since(sentAt), connectionDelay, "packet was relayed before the connection delay passed")
+	requireNoPacketCommitments(ctx, req, c0, channel.PortID, channel.ChannelID)
+}
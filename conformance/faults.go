@@ -0,0 +1,185 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// crashRecoveryPacketCount is the number of packets in flight when the relayer is killed.
+const crashRecoveryPacketCount = 5
+
+// TestRelayerCrashRecovery interrupts a running relayer while packets are in flight,
+// and asserts that every packet is still delivered exactly once after the relayer recovers.
+// If the relayer also implements ibc.NetworkFaultInjectableRelayer,
+// the relayer is additionally cut off from the chains' network while packets are sent.
+//
+// This test is skipped if the relayer does not implement ibc.FaultInjectableRelayer.
+func TestRelayerCrashRecovery(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	built := rf.Build(t, client, network, home)
+	r, ok := built.(ibc.FaultInjectableRelayer)
+	if !ok {
+		rep.TrackSkip(t, "skipping because relayer %T does not support fault injection", built)
+	}
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	// Each subtest sends from a fresh user to the same user's address on the counterparty,
+	// so that the received balance reflects only that subtest's transfers.
+	dstIbcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, c0.Config().Denom),
+	).IBCDenom()
+
+	sendTransfers := func(req *require.Assertions, user *ibctest.User, n int) []ibc.Tx {
+		txs := make([]ibc.Tx, n)
+		for i := range txs {
+			tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+				Address: user.Bech32Address(c1.Config().Bech32Prefix),
+				Denom:   c0.Config().Denom,
+				Amount:  testCoinAmount,
+			}, nil)
+			req.NoError(err)
+			req.NoError(tx.Validate())
+			txs[i] = tx
+		}
+		return txs
+	}
+
+	requireDelivered := func(req *require.Assertions, user *ibctest.User, txs []ibc.Tx) {
+		for _, tx := range txs {
+			ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+			req.NoError(err, "failed to get acknowledgement for packet %d", tx.Packet.Sequence)
+			req.NoError(ack.Validate(), "invalid acknowledgement for packet %d", tx.Packet.Sequence)
+		}
+
+		bal, err := c1.GetBalance(ctx, user.Bech32Address(c1.Config().Bech32Prefix), dstIbcDenom)
+		req.NoError(err)
+		req.Equal(int64(len(txs))*testCoinAmount, bal, "packets must be received exactly once")
+		requireNoPacketCommitments(ctx, req, c0, channel.PortID, channel.ChannelID)
+	}
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	t.Run("pause and resume", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		user := ibctest.GetAndFundTestUsers(t, ctx, "pause", userFaucetFund, c0)[0]
+
+		req.NoError(r.PauseRelayer(ctx, eRep))
+		txs := sendTransfers(req, user, 1)
+
+		// The paused relayer must not relay the packet.
+		_, err := test.PollForAck(ctx, c0, txs[0].Height, txs[0].Height+5, txs[0].Packet)
+		req.ErrorIs(err, test.ErrNotFound)
+
+		req.NoError(r.ResumeRelayer(ctx, eRep))
+		requireDelivered(req, user, txs)
+	})
+
+	t.Run("kill and restart", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		user := ibctest.GetAndFundTestUsers(t, ctx, "restart", userFaucetFund, c0)[0]
+
+		// Kill the relayer while it is in the middle of relaying the packets.
+		txs := sendTransfers(req, user, crashRecoveryPacketCount)
+		req.NoError(r.RestartRelayer(ctx, eRep))
+
+		requireDelivered(req, user, txs)
+	})
+
+	t.Run("disconnect and reconnect", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		nr, ok := built.(ibc.NetworkFaultInjectableRelayer)
+		if !ok {
+			rep.TrackSkip(t, "skipping because relayer %T does not support network fault injection", built)
+		}
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		user := ibctest.GetAndFundTestUsers(t, ctx, "disconnect", userFaucetFund, c0)[0]
+
+		req.NoError(nr.DisconnectRelayer(ctx, eRep))
+		txs := sendTransfers(req, user, 1)
+
+		// The relayer cannot reach either chain, so it must not relay the packet.
+		_, err := test.PollForAck(ctx, c0, txs[0].Height, txs[0].Height+5, txs[0].Packet)
+		req.ErrorIs(err, test.ErrNotFound)
+
+		// Reconnect the relayer in the background while more packets are sent.
+		reconnected := test.HealAfter(ctx, 10*time.Second, func(ctx context.Context) error {
+			return nr.ReconnectRelayer(ctx, eRep)
+		})
+		txs = append(txs, sendTransfers(req, user, 1)...)
+		req.NoError(<-reconnected)
+
+		requireDelivered(req, user, txs)
+	})
+}
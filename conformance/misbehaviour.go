@@ -0,0 +1,161 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerMisbehaviour asserts that the relayer freezes the light client of a chain that double signs.
+//
+// The first chain is forked by running a copy of one of its validators on an isolated network,
+// and the second chain's client of the first chain is updated with a header from the fork, as a malicious relayer would.
+// The relayer must then detect that the header conflicts with the first chain,
+// and freeze the client by submitting the misbehaviour from its own wallet.
+// This test is skipped if the first chain cannot be forked,
+// or the second chain's clients cannot be updated or its IBC state queried directly.
+//
+// Forking a chain and freezing a client leave both chains unusable for other tests,
+// so this test runs on chains of its own rather than as one of the cases of TestChainPair.
+func TestRelayerMisbehaviour(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.Misbehaviour)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	forkable, ok := c0.(ibc.DoubleSigningChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping because %s cannot be made to produce conflicting headers", c0.Config().ChainID)
+	}
+	updatable, ok := c1.(ibc.ClientUpdatingChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping because the clients of %s cannot be updated directly", c1.Config().ChainID)
+	}
+	querier, ok := c1.(ibc.IBCQueryingChain)
+	if !ok {
+		rep.TrackSkip(t, "skipping because the IBC state of %s cannot be queried directly", c1.Config().ChainID)
+	}
+
+	r := rf.Build(t, client, network, home)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		HomeDir:   home,
+		Client:    client,
+		NetworkID: network,
+	}))
+	defer ic.Close()
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	c0ChainID := c0.Config().ChainID
+	clientOnC1, err := findClientOf(ctx, r, eRep, c1.Config().ChainID, c0ChainID)
+	req.NoError(err)
+	req.False(clientOnC1.Frozen, "client %s is frozen before any misbehaviour", clientOnC1.ID)
+
+	misbehaviour, err := forkable.ConflictingHeaders(ctx, clientOnC1.LatestHeight)
+	req.NoError(err, "failed to get conflicting headers from %s", c0ChainID)
+	t.Logf("%s forked at height %d", c0ChainID, misbehaviour.Header1.GetHeight().GetRevisionHeight())
+
+	// Update the client with the fork's header, as a malicious relayer would.
+	// The header is above the client's latest height, so the client accepts it;
+	// it is up to the relayer to notice that the header conflicts with the first chain.
+	c1ChainID := c1.Config().ChainID
+	startHeight, err := c1.Height(ctx)
+	req.NoError(err)
+
+	forkHeader := misbehaviour.Header1
+	req.NoError(
+		updatable.UpdateClient(ctx, ibctest.FaucetAccountKeyName, clientOnC1.ID, forkHeader),
+		"failed to update client %s with the fork's header", clientOnC1.ID,
+	)
+
+	// An update that is itself detected as misbehaviour freezes the client without storing a consensus state,
+	// so the fork's consensus state shows that the update was accepted and left the client unfrozen.
+	cs, err := querier.QueryConsensusState(ctx, clientOnC1.ID, forkHeader.GetHeight().(clienttypes.Height))
+	req.NoError(err, "client %s has no consensus state at the fork's height", clientOnC1.ID)
+	req.Equal(forkHeader.ConsensusState().GetRoot(), cs.GetRoot(), "consensus state of client %s is not the fork's", clientOnC1.ID)
+
+	wallet, ok := r.GetWallet(c1ChainID)
+	req.True(ok, "relayer has no wallet on %s", c1ChainID)
+
+	for i := uint64(0); i < pollHeightMax; i++ {
+		req.NoError(test.WaitForBlocks(ctx, 1, c1))
+
+		clientOnC1, err = findClientOf(ctx, r, eRep, c1ChainID, c0ChainID)
+		req.NoError(err)
+		if !clientOnC1.Frozen {
+			continue
+		}
+
+		// The client must have been frozen by the relayer, rather than by anything else.
+		endHeight, err := c1.Height(ctx)
+		req.NoError(err)
+		for h := startHeight + 1; h <= endHeight; h++ {
+			found, err := updatable.Misbehaviours(ctx, h)
+			req.NoError(err)
+			for _, m := range found {
+				if m.ClientID == clientOnC1.ID && m.Signer == wallet.Address {
+					t.Logf("Relayer submitted misbehaviour to client %s at height %d", clientOnC1.ID, h)
+					return
+				}
+			}
+		}
+		req.Fail("client was frozen without the relayer's misbehaviour", "client %s on %s was frozen, but %s submitted no misbehaviour for it between heights %d and %d", clientOnC1.ID, c1ChainID, wallet.Address, startHeight+1, endHeight)
+	}
+	req.Fail("client was not frozen", "client %s of %s on %s was not frozen within %d blocks", clientOnC1.ID, c0ChainID, c1ChainID, pollHeightMax)
+}
+
+// findClientOf returns the client on chainID that tracks trackedChainID, as reported by r.
+func findClientOf(ctx context.Context, r ibc.Relayer, rep ibc.RelayerExecReporter, chainID, trackedChainID string) (ibc.ClientOutput, error) {
+	clients, err := r.GetClients(ctx, rep, chainID)
+	if err != nil {
+		return ibc.ClientOutput{}, fmt.Errorf("failed to get clients on %s: %w", chainID, err)
+	}
+	for _, c := range clients {
+		if c.ChainID == trackedChainID {
+			return c, nil
+		}
+	}
+	return ibc.ClientOutput{}, fmt.Errorf("no client of %s found on %s", trackedChainID, chainID)
+}
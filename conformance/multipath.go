@@ -0,0 +1,101 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerMultiplePaths links the same two chains over two separate paths,
+// starts a single relayer on both paths, and asserts that packets are relayed on each path.
+func TestRelayerMultiplePaths(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.MultiplePaths)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network, home)
+
+	pathNames := []string{"p0", "p1"}
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r")
+	for _, pathName := range pathNames {
+		ic.AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+	}
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	// Each path has its own clients, connection, and channel.
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, len(pathNames))
+	req.NotEqual(channels[0].ConnectionHops, channels[1].ConnectionHops, "paths must not share a connection")
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathNames...))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	// Get faucet address on destination chain for ibc transfer.
+	c1FaucetAddrBytes, err := c1.GetAddress(ctx, ibctest.FaucetAccountKeyName)
+	req.NoError(err)
+	c1FaucetAddr, err := types.Bech32ifyAddressBytes(c1.Config().Bech32Prefix, c1FaucetAddrBytes)
+	req.NoError(err)
+
+	txs := make([]ibc.Tx, len(channels))
+	for i, channel := range channels {
+		tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, ibctest.FaucetAccountKeyName, ibc.WalletAmount{
+			Address: c1FaucetAddr,
+			Denom:   c0.Config().Denom,
+			Amount:  testCoinAmount,
+		}, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		txs[i] = tx
+	}
+
+	for i, tx := range txs {
+		ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement for transfer on %s", channels[i].ChannelID)
+		req.NoError(ack.Validate(), "invalid acknowledgement for transfer on %s", channels[i].ChannelID)
+	}
+}
@@ -0,0 +1,41 @@
+package conformance
+
+import (
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// TestOption enables optional subtests of Test.
+type TestOption interface {
+	// testOption is a no-op to be more restrictive on what types can be used as TestOptions
+	testOption()
+}
+
+// TestOptionRelayerUpgrade runs TestRelayerUpgrade for the relayer factory named RelayerFactoryName,
+// upgrading the relayers it builds to NewImage.
+type TestOptionRelayerUpgrade struct {
+	RelayerFactoryName string
+	NewImage           ibc.DockerImage
+}
+
+func RelayerUpgrade(relayerFactoryName string, newImage ibc.DockerImage) TestOption {
+	return TestOptionRelayerUpgrade{
+		RelayerFactoryName: relayerFactoryName,
+		NewImage:           newImage,
+	}
+}
+
+func (opt TestOptionRelayerUpgrade) testOption() {}
+
+// relayerUpgradeImage returns the image that opts upgrade the relayers
+// built by the factory named rfName to, and whether there is one.
+func relayerUpgradeImage(opts []TestOption, rfName string) (ibc.DockerImage, bool) {
+	for _, opt := range opts {
+		switch o := opt.(type) {
+		case TestOptionRelayerUpgrade:
+			if o.RelayerFactoryName == rfName {
+				return o.NewImage, true
+			}
+		}
+	}
+	return ibc.DockerImage{}, false
+}
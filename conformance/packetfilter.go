@@ -0,0 +1,119 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelayerPacketFilter creates two channels on a single path whose packet filter allows only one of them,
+// and asserts that packets are relayed on the allowed channel but not on the other.
+func TestRelayerPacketFilter(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+	requireCapabilities(t, rep, rf, relayer.PacketFilter)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network, home)
+
+	// The filter is part of the path, so it is configured before the channels exist.
+	// The chains are new, so the path's first channel is channel-0 and the second is channel-1.
+	// Allowing the second channel ensures that the filter is not simply satisfied by the path's first channel.
+	const allowedChannelID = "channel-1"
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+
+			PathOptions: ibc.PathOptions{
+				PacketFilter: ibc.PacketFilter{
+					Policy:     ibc.PacketFilterAllowList,
+					ChannelIDs: []string{allowedChannelID},
+				},
+			},
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	// Building the interchain created the path's first channel; add the second.
+	req.NoError(r.CreateChannel(ctx, eRep, pathName, ibc.DefaultChannelOpts()))
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 2)
+	_, ok := findChannel(channels, allowedChannelID)
+	req.True(ok, "allowed channel %s not found", allowedChannelID)
+
+	var deniedChannelID string
+	for _, ch := range channels {
+		if ch.ChannelID != allowedChannelID {
+			deniedChannelID = ch.ChannelID
+		}
+	}
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "filter", userFaucetFund, c0)[0]
+	sendTransfer := func(channelID string) ibc.Tx {
+		tx, err := c0.SendIBCTransfer(ctx, channelID, user.KeyName, ibc.WalletAmount{
+			Address: user.Bech32Address(c1.Config().Bech32Prefix),
+			Denom:   c0.Config().Denom,
+			Amount:  testCoinAmount,
+		}, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		return tx
+	}
+
+	deniedTx := sendTransfer(deniedChannelID)
+	allowedTx := sendTransfer(allowedChannelID)
+
+	ack, err := test.PollForAck(ctx, c0, allowedTx.Height, allowedTx.Height+pollHeightMax, allowedTx.Packet)
+	req.NoError(err, "failed to get acknowledgement on allowed channel %s", allowedChannelID)
+	req.NoError(ack.Validate())
+
+	// The denied packet was sent first, so the relayer has had at least as long to relay it.
+	_, err = test.PollForAck(ctx, c0, deniedTx.Height, deniedTx.Height+5, deniedTx.Packet)
+	req.ErrorIs(err, test.ErrNotFound, "packet relayed on denied channel %s", deniedChannelID)
+}
@@ -0,0 +1,196 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// redundantPacketCount is the number of packets sent while multiple relayers compete to relay them.
+const redundantPacketCount = 5
+
+// TestRedundantRelayers runs one relayer built from each of the given factories on the same channel at once,
+// and asserts that every packet is delivered exactly once.
+// The factories may build different relayer implementations, or the same factory may be given more than once.
+//
+// The first relayer links the path, and the others share its clients and connection,
+// so they must implement ibc.PathSharingRelayer; otherwise the test is skipped.
+// The signer of every MsgRecvPacket is logged, to report which relayer delivered each packet.
+func TestRedundantRelayers(t *testing.T, cf ibctest.ChainFactory, rfs []ibctest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	if len(rfs) < 2 {
+		panic(fmt.Errorf("expected at least 2 relayer factories, got %d", len(rfs)))
+	}
+
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	// Each relayer gets its own home directory,
+	// so that relayers built by the same factory do not share configuration.
+	relayers := make([]ibc.Relayer, len(rfs))
+	names := make([]string, len(rfs))
+	for i, rf := range rfs {
+		relayers[i] = rf.Build(t, client, network, ibctest.TempDir(t))
+		names[i] = fmt.Sprintf("r%d-%s", i, rf.Name())
+
+		if _, ok := relayers[i].(ibc.PathSharingRelayer); i > 0 && !ok {
+			rep.TrackSkip(t, "skipping because relayer %s cannot share a path with another relayer", rf.Name())
+		}
+	}
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1)
+	for i, r := range relayers {
+		ic.AddRelayer(r, names[i])
+	}
+	for i, r := range relayers {
+		link := ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		}
+		if i > 0 {
+			link.ShareWith = relayers[0]
+		}
+		ic.AddLink(link)
+	}
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	testName := t.Name()
+	dbPath := filepath.Join(ibctest.TempDir(t), "blocks.db")
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          testName,
+		HomeDir:           ibctest.TempDir(t),
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+
+		BlockDatabaseFile: dbPath,
+	}))
+	defer ic.Close()
+
+	// The shared path has a single channel, visible to every relayer.
+	channels, err := relayers[0].GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+	for i, r := range relayers[1:] {
+		cs, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+		req.NoError(err)
+		_, ok := findChannel(cs, channel.ChannelID)
+		req.True(ok, "relayer %s does not see channel %s", names[i+1], channel.ChannelID)
+	}
+
+	for _, r := range relayers {
+		r := r
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+		defer func() {
+			if err := r.StopRelayer(ctx, eRep); err != nil {
+				t.Logf("error stopping relayer: %v", err)
+			}
+		}()
+	}
+
+	user := ibctest.GetAndFundTestUsers(t, ctx, "redundant", userFaucetFund, c0)[0]
+	dstAddr := user.Bech32Address(c1.Config().Bech32Prefix)
+
+	txs := make([]ibc.Tx, redundantPacketCount)
+	for i := range txs {
+		tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+			Address: dstAddr,
+			Denom:   c0.Config().Denom,
+			Amount:  testCoinAmount,
+		}, nil)
+		req.NoError(err)
+		req.NoError(tx.Validate())
+		txs[i] = tx
+	}
+
+	for _, tx := range txs {
+		ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+		req.NoError(err, "failed to get acknowledgement for packet %d", tx.Packet.Sequence)
+		req.NoError(ack.Validate(), "invalid acknowledgement for packet %d", tx.Packet.Sequence)
+	}
+
+	// Redundant deliveries would be reflected in the received balance.
+	dstIbcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, c0.Config().Denom),
+	).IBCDenom()
+	bal, err := c1.GetBalance(ctx, dstAddr, dstIbcDenom)
+	req.NoError(err)
+	req.Equal(int64(redundantPacketCount)*testCoinAmount, bal, "packets must be received exactly once")
+
+	t.Run("report MsgRecvPacket signers", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		// Give the block collector time to save the blocks containing the packets.
+		req.NoError(test.WaitForBlocks(ctx, 2, c1))
+
+		signerNames := make(map[string]string, len(relayers))
+		for i, r := range relayers {
+			wallet, ok := r.GetWallet(c1.Config().ChainID)
+			req.True(ok, "relayer %s has no wallet on %s", names[i], c1.Config().ChainID)
+			signerNames[wallet.Address] = names[i]
+		}
+
+		db, err := blockdb.ConnectDB(ctx, dbPath)
+		req.NoError(err)
+		defer db.Close()
+
+		rows, err := db.QueryContext(ctx, `SELECT
+CAST(json_extract(raw, "$.packet.sequence") AS INTEGER), signer
+FROM v_cosmos_messages
+WHERE test_case_name = ? AND chain_id = ? AND type = "/ibc.core.channel.v1.MsgRecvPacket" AND channel_id = ?
+ORDER BY block_height ASC, msg_n ASC
+`, testName, c1.Config().ChainID, channel.ChannelID)
+		req.NoError(err)
+		defer rows.Close()
+
+		submitted := make(map[uint64]int)
+		for rows.Next() {
+			var (
+				seq    uint64
+				signer string
+			)
+			req.NoError(rows.Scan(&seq, &signer))
+
+			name, ok := signerNames[signer]
+			req.True(ok, "MsgRecvPacket for packet %d signed by %s, which is not a relayer", seq, signer)
+			t.Logf("Packet %d: MsgRecvPacket submitted by relayer %s", seq, name)
+
+			submitted[seq]++
+		}
+		req.NoError(rows.Err())
+
+		for _, tx := range txs {
+			req.NotZero(submitted[tx.Packet.Sequence], "no MsgRecvPacket found for packet %d", tx.Packet.Sequence)
+		}
+	})
+}
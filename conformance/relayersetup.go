@@ -71,25 +71,50 @@ func TestRelayerSetup(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerF
 		rep.TrackTest(t)
 		req := require.New(rep.TestifyT(t))
 
-		req.NoError(r.GeneratePath(ctx, rep.RelayerExecReporter(t), c0.Config().ChainID, c1.Config().ChainID, pathName))
+		req.NoError(r.GeneratePath(ctx, rep.RelayerExecReporter(t), c0.Config().ChainID, c1.Config().ChainID, pathName, ibc.PathOptions{}))
 	})
 	if t.Failed() {
 		return
 	}
 
+	var client0, client1 ibc.ClientOutput
 	t.Run("create clients", func(t *testing.T) {
 		rep.TrackTest(t)
 		req := require.New(rep.TestifyT(t))
 
-		req.NoError(r.CreateClients(ctx, rep.RelayerExecReporter(t), pathName))
+		eRep := rep.RelayerExecReporter(t)
+		req.NoError(r.CreateClients(ctx, eRep, pathName))
+
+		// The client isn't created immediately -- wait for two blocks to ensure the clients are ready.
+		req.NoError(test.WaitForBlocks(ctx, 2, c0, c1))
+
+		// Each chain must have a single client tracking the other chain.
+		clients0, err := r.GetClients(ctx, eRep, c0.Config().ChainID)
+		req.NoError(err)
+
+		req.Len(clients0, 1)
+		client0 = clients0[0]
+		req.NotEmpty(client0.ID)
+		req.Equal(c1.Config().ChainID, client0.ChainID)
+		req.Positive(client0.TrustingPeriod)
+		req.False(client0.LatestHeight.IsZero())
+		req.False(client0.Frozen)
+
+		clients1, err := r.GetClients(ctx, eRep, c1.Config().ChainID)
+		req.NoError(err)
+
+		req.Len(clients1, 1)
+		client1 = clients1[0]
+		req.NotEmpty(client1.ID)
+		req.Equal(c0.Config().ChainID, client1.ChainID)
+		req.Positive(client1.TrustingPeriod)
+		req.False(client1.LatestHeight.IsZero())
+		req.False(client1.Frozen)
 	})
 	if t.Failed() {
 		return
 	}
 
-	// The client isn't created immediately -- wait for two blocks to ensure the clients are ready.
-	req.NoError(test.WaitForBlocks(ctx, 2, c0, c1))
-
 	t.Run("create connections", func(t *testing.T) {
 		rep.TrackTest(t)
 		req := require.New(rep.TestifyT(t))
@@ -116,11 +141,19 @@ func TestRelayerSetup(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerF
 		req.NotEmpty(conn1.ClientID)
 		req.Equal(conn1.State, conntypes.OPEN.String())
 
+		// The connections must use the clients created earlier.
+		req.Equal(client0.ID, conn0.ClientID)
+		req.Equal(client1.ID, conn1.ClientID)
+
 		// Now validate counterparties.
 		req.Equal(conn0.Counterparty.ClientId, conn1.ClientID)
 		req.Equal(conn0.Counterparty.ConnectionId, conn1.ID)
 		req.Equal(conn1.Counterparty.ClientId, conn0.ClientID)
 		req.Equal(conn1.Counterparty.ConnectionId, conn0.ID)
+
+		// The chains must agree with the relayer.
+		requireChainConnection(ctx, req, c0, conn0)
+		requireChainConnection(ctx, req, c1, conn1)
 	})
 	if t.Failed() {
 		return
@@ -174,5 +207,9 @@ func TestRelayerSetup(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerF
 		req.Equal(ch1.Counterparty, ibc.ChannelCounterparty{PortID: "transfer", ChannelID: ch0.ChannelID})
 		req.Equal(ch1.Version, "ics20-1")
 		req.Equal(ch1.PortID, "transfer")
+
+		// The chains must agree with the relayer.
+		requireChainChannel(ctx, req, c0, ch0)
+		requireChainChannel(ctx, req, c1, ch1)
 	})
 }
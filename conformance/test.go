@@ -30,12 +30,14 @@ package conformance
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
 	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
 	"github.com/strangelove-ventures/ibctest"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/label"
@@ -54,6 +56,8 @@ const (
 
 type RelayerTestCase struct {
 	Config RelayerTestCaseConfig
+	// the running relayer, set once the relayer has started
+	Relayer ibc.Relayer
 	// user on source chain
 	Users []*ibctest.User
 	// temp storage in between test phases
@@ -64,7 +68,7 @@ type RelayerTestCaseConfig struct {
 	Name string
 	// which relayer capabilities are required to run this test
 	RequiredRelayerCapabilities []relayer.Capability
-	// function to run after the chains are started but before the relayer is started
+	// optional function to run after the chains are started but before the relayer is started
 	// e.g. send a transfer and wait for it to timeout so that the relayer will handle it once it is timed out
 	PreRelayerStart func(context.Context, *testing.T, *RelayerTestCase, ibc.Chain, ibc.Chain, []ibc.ChannelOutput)
 	// test after chains and relayers are started
@@ -100,6 +104,11 @@ var relayerTestCaseConfigs = [...]RelayerTestCaseConfig{
 		Test:                        testPacketRelayFail,
 		TestLabels:                  []label.Test{label.Timeout, label.TimestampTimeout},
 	},
+	{
+		Name:                        "ordered channel",
+		RequiredRelayerCapabilities: []relayer.Capability{relayer.OrderedChannels},
+		Test:                        testOrderedChannel,
+	},
 }
 
 // requireCapabilities tracks skipping t, if the relayer factory cannot satisfy the required capabilities.
@@ -192,7 +201,9 @@ func sendIBCTransfersFromBothChainsWithTimeout(
 // so that it can properly group subtests in a single invocation.
 // If the subtest configuration does not meet your needs,
 // you can directly call one of the other exported Test functions, such as TestChainPair.
-func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory, rep *testreporter.Reporter) {
+//
+// Subtests that need more than the factories, such as upgrading the relayer, run only when enabled through opts.
+func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory, rep *testreporter.Reporter, opts ...TestOption) {
 	// Validate chain factory counts up front.
 	counts := make(map[int]bool)
 	for _, cf := range cfs {
@@ -242,6 +253,64 @@ func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory
 
 								TestRelayerFlushing(t, cf, rf, rep)
 							})
+
+							t.Run("channel close", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelClose(t, cf, rf, rep)
+							})
+
+							t.Run("misbehaviour", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMisbehaviour(t, cf, rf, rep)
+							})
+
+							t.Run("connection delay", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerConnectionDelay(t, cf, rf, rep)
+							})
+
+							t.Run("multiple paths", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMultiplePaths(t, cf, rf, rep)
+							})
+
+							t.Run("packet filter", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerPacketFilter(t, cf, rf, rep)
+							})
+
+							t.Run("crash recovery", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerCrashRecovery(t, cf, rf, rep)
+							})
+
+							t.Run("redundant relayers", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRedundantRelayers(t, cf, []ibctest.RelayerFactory{rf, rf}, rep)
+							})
+
+							if newImage, ok := relayerUpgradeImage(opts, rf.Name()); ok {
+								t.Run("relayer upgrade", func(t *testing.T) {
+									rep.TrackTest(t)
+									rep.TrackParallel(t)
+
+									TestRelayerUpgrade(t, cf, rf, rep, newImage)
+								})
+							}
 						})
 					}
 				})
@@ -258,6 +327,7 @@ func Test(t *testing.T, cfs []ibctest.ChainFactory, rfs []ibctest.RelayerFactory
 // 2. Proper handling of no timeout from A -> B and B -> A.
 // 3. Proper handling of height timeout from A -> B and B -> A.
 // 4. Proper handling of timestamp timeout from A -> B and B -> A.
+// 5. Completion of an ordered channel handshake initiated by Chain A, if Chain A supports interchain accounts.
 func TestChainPair(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter) {
 	client, network := ibctest.DockerSetup(t)
 
@@ -294,7 +364,9 @@ func TestChainPair(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFact
 			// fund a user wallet on both chains, save on test case
 			testCase.Users = ibctest.GetAndFundTestUsers(t, ctx, strings.ReplaceAll(testCase.Config.Name, " ", "-"), userFaucetFund, srcChain, dstChain)
 			// run test specific pre relayer start action
-			testCase.Config.PreRelayerStart(ctx, t, &testCase, srcChain, dstChain, channels)
+			if testCase.Config.PreRelayerStart != nil {
+				testCase.Config.PreRelayerStart(ctx, t, &testCase, srcChain, dstChain, channels)
+			}
 		}
 		preRelayerStartFuncs = append(preRelayerStartFuncs, preRelayerStartFunc)
 	}
@@ -305,11 +377,12 @@ func TestChainPair(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFact
 	// creates a faucet account on the both chains (separate fullnode)
 	// funds faucet accounts in genesis
 	home := ibctest.TempDir(t)
-	_, channels, err := ibctest.StartChainPairAndRelayer(t, ctx, rep, client, network, home, srcChain, dstChain, rf, preRelayerStartFuncs)
+	relayerImpl, channels, err := ibctest.StartChainPairAndRelayer(t, ctx, rep, client, network, home, srcChain, dstChain, rf, preRelayerStartFuncs)
 	req.NoError(err, "failed to StartChainPairAndRelayer")
 
 	for _, testCase := range testCases {
 		testCase := testCase
+		testCase.Relayer = relayerImpl
 		t.Run(testCase.Config.Name, func(t *testing.T) {
 			rep.TrackTest(t, testCase.Config.TestLabels...)
 			requireCapabilities(t, rep, rf, testCase.Config.RequiredRelayerCapabilities...)
@@ -507,3 +580,84 @@ func testPacketRelayFail(
 	req.Equal(dstInitialBalance-totalFees, dstFinalBalance)
 	// [END] assert on destination to source transfer
 }
+
+// Ensure that the relayer completes the handshake for an ordered channel opened by the chain itself.
+// Interchain accounts are the only built-in application that uses ordered channels,
+// so this test is skipped if the source chain cannot register an interchain account.
+func testOrderedChannel(
+	ctx context.Context,
+	t *testing.T,
+	testCase *RelayerTestCase,
+	rep *testreporter.Reporter,
+	srcChain ibc.Chain,
+	dstChain ibc.Chain,
+	channels []ibc.ChannelOutput,
+) {
+	req := require.New(rep.TestifyT(t))
+
+	srcChainCfg := srcChain.Config()
+	srcUser := testCase.Users[0]
+	connectionID := channels[0].ConnectionHops[0]
+
+	_, err := srcChain.RegisterInterchainAccount(ctx, srcUser.KeyName, connectionID)
+	if errors.Is(err, ibc.ErrInterchainAccountsNotSupported) {
+		rep.TrackSkip(t, "skipping because %s does not support interchain accounts: %v", srcChainCfg.ChainID, err)
+	}
+	req.NoError(err, "failed to register an interchain account on %s", srcChainCfg.ChainID)
+
+	eRep := rep.RelayerExecReporter(t)
+
+	ordered, err := pollForOrderedChannel(ctx, testCase.Relayer, eRep, srcChain, dstChain, connectionID)
+	req.NoError(err)
+
+	dstChannels, err := testCase.Relayer.GetChannels(ctx, eRep, dstChain.Config().ChainID)
+	req.NoError(err, "failed to get channels on destination chain")
+
+	counterparty, ok := findChannel(dstChannels, ordered.Counterparty.ChannelID)
+	req.True(ok, "counterparty of ordered channel %s not found on destination chain", ordered.ChannelID)
+	req.Equal(chantypes.ORDERED.String(), counterparty.Ordering)
+	req.Equal(chantypes.OPEN.String(), counterparty.State)
+
+	icaAddr, err := srcChain.QueryInterchainAccount(ctx, connectionID, srcUser.Bech32Address(srcChainCfg.Bech32Prefix))
+	req.NoError(err, "failed to query interchain account")
+	req.NotEmpty(icaAddr, "interchain account was not created")
+}
+
+// pollForOrderedChannel waits for an open, ordered channel on connectionID to appear on srcChain,
+// as reported by r.
+func pollForOrderedChannel(
+	ctx context.Context,
+	r ibc.Relayer,
+	rep ibc.RelayerExecReporter,
+	srcChain, dstChain ibc.Chain,
+	connectionID string,
+) (ibc.ChannelOutput, error) {
+	srcChainID := srcChain.Config().ChainID
+	for i := uint64(0); i < pollHeightMax; i++ {
+		if err := test.WaitForBlocks(ctx, 1, srcChain, dstChain); err != nil {
+			return ibc.ChannelOutput{}, fmt.Errorf("failed to wait for blocks: %w", err)
+		}
+
+		channels, err := r.GetChannels(ctx, rep, srcChainID)
+		if err != nil {
+			return ibc.ChannelOutput{}, fmt.Errorf("failed to get channels on %s: %w", srcChainID, err)
+		}
+		for _, c := range channels {
+			if c.Ordering == chantypes.ORDERED.String() && c.State == chantypes.OPEN.String() &&
+				len(c.ConnectionHops) == 1 && c.ConnectionHops[0] == connectionID {
+				return c, nil
+			}
+		}
+	}
+	return ibc.ChannelOutput{}, fmt.Errorf("no ordered channel on %s was opened on %s", connectionID, srcChainID)
+}
+
+// findChannel returns the channel with the given ID from channels.
+func findChannel(channels []ibc.ChannelOutput, channelID string) (ibc.ChannelOutput, bool) {
+	for _, c := range channels {
+		if c.ChannelID == channelID {
+			return c, true
+		}
+	}
+	return ibc.ChannelOutput{}, false
+}
@@ -0,0 +1,159 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	transfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/test"
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradePacketCount is the number of packets left for the upgraded relayer to relay.
+const upgradePacketCount = 3
+
+// TestRelayerUpgrade links a path with the relayer built by rf,
+// sends packets that the relayer has not yet relayed, upgrades the relayer to newImage,
+// and asserts that the upgraded relayer delivers every packet exactly once.
+// The upgraded relayer must pick up the configuration and keys left by the previous release.
+//
+// Typically rf builds an older release of the relayer, and newImage is the release under test.
+// This test is skipped if the relayer does not implement ibc.UpgradeableRelayer.
+func TestRelayerUpgrade(t *testing.T, cf ibctest.ChainFactory, rf ibctest.RelayerFactory, rep *testreporter.Reporter, newImage ibc.DockerImage) {
+	rep.TrackTest(t)
+
+	home := ibctest.TempDir(t)
+	client, network := ibctest.DockerSetup(t)
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	built := rf.Build(t, client, network, home)
+	r, ok := built.(ibc.UpgradeableRelayer)
+	if !ok {
+		rep.TrackSkip(t, "skipping because relayer %T cannot be upgraded", built)
+	}
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path: pathName,
+		})
+
+	ctx := context.Background()
+	eRep := rep.RelayerExecReporter(t)
+
+	req.NoError(ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		HomeDir:           home,
+		Client:            client,
+		NetworkID:         network,
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	defer ic.Close()
+
+	channels, err := r.GetChannels(ctx, eRep, c0.Config().ChainID)
+	req.NoError(err)
+	req.Len(channels, 1)
+	channel := channels[0]
+
+	// Each subtest sends from a fresh user to the same user's address on the counterparty,
+	// so that the received balance reflects only that subtest's transfers.
+	dstIbcDenom := transfertypes.ParseDenomTrace(
+		transfertypes.GetPrefixedDenom(channel.Counterparty.PortID, channel.Counterparty.ChannelID, c0.Config().Denom),
+	).IBCDenom()
+
+	sendTransfers := func(req *require.Assertions, user *ibctest.User, n int) []ibc.Tx {
+		txs := make([]ibc.Tx, n)
+		for i := range txs {
+			tx, err := c0.SendIBCTransfer(ctx, channel.ChannelID, user.KeyName, ibc.WalletAmount{
+				Address: user.Bech32Address(c1.Config().Bech32Prefix),
+				Denom:   c0.Config().Denom,
+				Amount:  testCoinAmount,
+			}, nil)
+			req.NoError(err)
+			req.NoError(tx.Validate())
+			txs[i] = tx
+		}
+		return txs
+	}
+
+	requireDelivered := func(req *require.Assertions, user *ibctest.User, txs []ibc.Tx) {
+		for _, tx := range txs {
+			ack, err := test.PollForAck(ctx, c0, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+			req.NoError(err, "failed to get acknowledgement for packet %d", tx.Packet.Sequence)
+			req.NoError(ack.Validate(), "invalid acknowledgement for packet %d", tx.Packet.Sequence)
+		}
+
+		bal, err := c1.GetBalance(ctx, user.Bech32Address(c1.Config().Bech32Prefix), dstIbcDenom)
+		req.NoError(err)
+		req.Equal(int64(len(txs))*testCoinAmount, bal, "packets must be received exactly once")
+	}
+
+	// Confirm that the original release relays before upgrading it.
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	before := ibctest.GetAndFundTestUsers(t, ctx, "before", userFaucetFund, c0)[0]
+	requireDelivered(req, before, sendTransfers(req, before, 1))
+
+	// Leave packets for the upgraded relayer.
+	req.NoError(r.StopRelayer(ctx, eRep))
+	pending := ibctest.GetAndFundTestUsers(t, ctx, "pending", userFaucetFund, c0)[0]
+	pendingTxs := sendTransfers(req, pending, upgradePacketCount)
+
+	// The subtests leave the upgraded relayer running.
+	defer func() {
+		if err := r.StopRelayer(ctx, eRep); err != nil {
+			t.Logf("error stopping relayer: %v", err)
+		}
+	}()
+
+	t.Run("upgrade stopped relayer", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		req.NoError(r.UpgradeRelayer(ctx, eRep, newImage))
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+
+		requireDelivered(req, pending, pendingTxs)
+	})
+	if t.Failed() {
+		return
+	}
+
+	t.Run("upgrade running relayer", func(t *testing.T) {
+		rep.TrackTest(t)
+
+		eRep := rep.RelayerExecReporter(t)
+
+		req := require.New(rep.TestifyT(t))
+
+		// Upgrading again to the same release restarts the running relayer,
+		// which must relay the packets in flight.
+		user := ibctest.GetAndFundTestUsers(t, ctx, "running", userFaucetFund, c0)[0]
+		txs := sendTransfers(req, user, upgradePacketCount)
+		req.NoError(r.UpgradeRelayer(ctx, eRep, newImage))
+
+		requireDelivered(req, user, txs)
+	})
+}
@@ -2,10 +2,20 @@ package ibc
 
 import (
 	"context"
+	"errors"
 
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/cosmos/ibc-go/v3/modules/core/exported"
+	ibctm "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
 	"github.com/docker/docker/client"
 )
 
+// ErrInterchainAccountsNotSupported is returned, possibly wrapped, by the interchain account methods of a Chain
+// that cannot control interchain accounts, such as a chain whose binary has no interchain accounts controller.
+var ErrInterchainAccountsNotSupported = errors.New("interchain accounts not supported")
+
 type Chain interface {
 	// fetch chain configuration
 	Config() ChainConfig
@@ -100,3 +110,69 @@ type Chain interface {
 	// QueryInterchainAccount will query the interchain account that was created on behalf of the specified address.
 	QueryInterchainAccount(ctx context.Context, connectionID, address string) (string, error)
 }
+
+// DoubleSigningChain is an optional interface for chains that can be made to produce conflicting headers,
+// the evidence of light client misbehaviour.
+type DoubleSigningChain interface {
+	Chain
+
+	// ConflictingHeaders forks the chain, if it has not already been forked, by running a copy of a validator
+	// isolated from the chain's network, so that the validator signs two different blocks at the same heights.
+	// It returns a misbehaviour whose Header1 is from the fork and whose Header2 is from the chain, at the same height,
+	// both verifiable by a light client of the chain with a consensus state at trustedHeight.
+	// The misbehaviour's client ID is not set.
+	ConflictingHeaders(ctx context.Context, trustedHeight clienttypes.Height) (*ibctm.Misbehaviour, error)
+}
+
+// ClientUpdatingChain is an optional interface for chains whose light clients can be updated with a given header,
+// rather than only with headers a relayer fetches from the counterparty.
+type ClientUpdatingChain interface {
+	Chain
+
+	// UpdateClient updates the light client with clientID using header, in a transaction signed by keyName.
+	UpdateClient(ctx context.Context, keyName, clientID string, header *ibctm.Header) error
+
+	// Misbehaviours returns the misbehaviour submitted to the chain's light clients in the block at height.
+	Misbehaviours(ctx context.Context, height uint64) ([]ClientMisbehaviour, error)
+}
+
+// IBCQueryingChain is an optional interface for chains whose IBC state can be queried directly,
+// so that tests can check the state of a chain independently of the relayer under test.
+type IBCQueryingChain interface {
+	Chain
+
+	// QueryClientStates returns every light client on the chain, with their client states unpacked.
+	QueryClientStates(ctx context.Context) (clienttypes.IdentifiedClientStates, error)
+
+	// QueryClientState returns the state of the light client with clientID.
+	QueryClientState(ctx context.Context, clientID string) (exported.ClientState, error)
+
+	// QueryConsensusState returns the consensus state at height of the light client with clientID.
+	QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (exported.ConsensusState, error)
+
+	// QueryConnections returns every connection end on the chain.
+	QueryConnections(ctx context.Context) ([]*conntypes.IdentifiedConnection, error)
+
+	// QueryConnection returns the connection end with connectionID.
+	QueryConnection(ctx context.Context, connectionID string) (*conntypes.ConnectionEnd, error)
+
+	// QueryChannels returns every channel end on the chain.
+	QueryChannels(ctx context.Context) ([]*chantypes.IdentifiedChannel, error)
+
+	// QueryChannel returns the channel end with portID and channelID.
+	QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error)
+
+	// QueryPacketCommitments returns the sequences of the packets sent on the channel
+	// that have been neither acknowledged nor timed out.
+	QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]uint64, error)
+
+	// QueryPacketReceipt reports whether the packet with sequence was received on the channel.
+	QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// QueryPacketAcknowledgement returns the commitment to the acknowledgement written
+	// for the packet with sequence received on the channel.
+	QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
+
+	// QueryNextSequenceReceive returns the sequence of the next packet to be received on an ordered channel.
+	QueryNextSequenceReceive(ctx context.Context, portID, channelID string) (uint64, error)
+}
@@ -36,7 +36,7 @@ type Relayer interface {
 	AddChainConfiguration(ctx context.Context, rep RelayerExecReporter, chainConfig ChainConfig, keyName, rpcAddr, grpcAddr string) error
 
 	// generate new path between two chains
-	GeneratePath(ctx context.Context, rep RelayerExecReporter, srcChainID, dstChainID, pathName string) error
+	GeneratePath(ctx context.Context, rep RelayerExecReporter, srcChainID, dstChainID, pathName string, opts PathOptions) error
 
 	// setup channels, connections, and clients
 	LinkPath(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) error
@@ -50,10 +50,13 @@ type Relayer interface {
 	// GetConnections returns a slice of IBC connection details composed of the details for each connection on a specified chain.
 	GetConnections(ctx context.Context, rep RelayerExecReporter, chainID string) (ConnectionOutputs, error)
 
-	// After configuration is initialized, begin relaying.
+	// GetClients returns the details of each IBC light client on a specified chain.
+	GetClients(ctx context.Context, rep RelayerExecReporter, chainID string) ([]ClientOutput, error)
+
+	// After configuration is initialized, begin relaying on the given paths.
 	// This method is intended to create a background worker that runs the relayer.
 	// You must call StopRelayer to cleanly stop the relaying.
-	StartRelayer(ctx context.Context, rep RelayerExecReporter, pathName string) error
+	StartRelayer(ctx context.Context, rep RelayerExecReporter, pathNames ...string) error
 
 	// StopRelayer stops a relayer that started work through StartRelayer.
 	StopRelayer(ctx context.Context, rep RelayerExecReporter) error
@@ -75,6 +78,12 @@ type Relayer interface {
 	// CreateChannel creates a channel on the given path with the provided options.
 	CreateChannel(ctx context.Context, rep RelayerExecReporter, pathName string, opts CreateChannelOptions) error
 
+	// CloseChannel closes the channel with the given ID on the path's source chain,
+	// and its counterparty on the path's destination chain.
+	// If the source end is already closed, such as after a timeout on an ordered channel,
+	// only the counterparty is closed.
+	CloseChannel(ctx context.Context, rep RelayerExecReporter, pathName, channelID string) error
+
 	// UseDockerNetwork reports whether the relayer is run in the same docker network as the other chains.
 	//
 	// If false, the relayer will connect to the localhost-exposed ports instead of the docker hosts.
@@ -84,6 +93,68 @@ type Relayer interface {
 	UseDockerNetwork() bool
 }
 
+// FaultInjectableRelayer is an optional interface for relayers whose running process,
+// started through StartRelayer, can be interrupted in order to test how the relayer recovers.
+type FaultInjectableRelayer interface {
+	Relayer
+
+	// PauseRelayer suspends the running relayer without stopping it.
+	PauseRelayer(ctx context.Context, rep RelayerExecReporter) error
+
+	// ResumeRelayer resumes a relayer that was suspended through PauseRelayer.
+	ResumeRelayer(ctx context.Context, rep RelayerExecReporter) error
+
+	// RestartRelayer abruptly kills the running relayer,
+	// without giving it a chance to finish any in-flight work,
+	// and then starts it again on the same paths.
+	RestartRelayer(ctx context.Context, rep RelayerExecReporter) error
+}
+
+// NetworkFaultInjectableRelayer is an optional interface for relayers whose running process,
+// started through StartRelayer, can be cut off from the chains it relays between,
+// in order to test how the relayer recovers from losing connectivity to the chains' RPC endpoints.
+type NetworkFaultInjectableRelayer interface {
+	Relayer
+
+	// DisconnectRelayer disconnects the running relayer from the network shared with the chains, without stopping it.
+	DisconnectRelayer(ctx context.Context, rep RelayerExecReporter) error
+
+	// ReconnectRelayer connects a relayer that was disconnected through DisconnectRelayer to the network again.
+	ReconnectRelayer(ctx context.Context, rep RelayerExecReporter) error
+}
+
+// PathSharingRelayer is an optional interface for relayers that can relay on the clients and connection
+// of a path that was linked by another relayer, so that multiple relayers compete to relay the same channels.
+type PathSharingRelayer interface {
+	Relayer
+
+	// GenerateSharedPath is used in place of GeneratePath and LinkPath.
+	// It generates a path between the chains of src and dst
+	// that relays on their existing clients and connection, rather than creating new ones.
+	GenerateSharedPath(ctx context.Context, rep RelayerExecReporter, pathName string, src, dst PathEnd) error
+}
+
+// UpgradeableRelayer is an optional interface for relayers that can switch to a different release
+// while keeping their configuration and keys, in order to test upgrades between relayer releases.
+type UpgradeableRelayer interface {
+	Relayer
+
+	// UpgradeRelayer runs every subsequent relayer command with newImage.
+	// If the relayer is running, it is stopped and started again on the same paths with newImage.
+	// If newImage cannot be obtained, the relayer is left running the previous release.
+	UpgradeRelayer(ctx context.Context, rep RelayerExecReporter, newImage DockerImage) error
+}
+
+// MetricsRelayer is an optional interface for relayers that serve Prometheus metrics while running.
+type MetricsRelayer interface {
+	Relayer
+
+	// MetricsURL returns the URL, reachable from the host running the test,
+	// of the Prometheus metrics endpoint of the relayer started through StartRelayer.
+	// It returns an empty string if the relayer does not serve metrics or is not currently running.
+	MetricsURL(ctx context.Context) (string, error)
+}
+
 // CreateChannelOptions contains the configuration for creating a channel.
 type CreateChannelOptions struct {
 	SourcePortName string
@@ -148,6 +219,91 @@ func (o Order) Validate() error {
 	return chantypes.ErrInvalidChannelOrdering
 }
 
+// PathOptions contains the configuration for generating a path.
+// The zero value generates a path that relays packets on every channel.
+type PathOptions struct {
+	// PacketFilter restricts the channels on which the relayer relays packets.
+	PacketFilter PacketFilter
+
+	// ConnectionDelay is the delay period of the connections the relayer creates on the path.
+	// A packet sent over such a connection cannot be received until the delay has passed
+	// since the receiving chain's client was updated with the block that committed the packet.
+	ConnectionDelay time.Duration
+}
+
+// Validate will check that the specified PathOptions are valid.
+func (opts PathOptions) Validate() error {
+	if opts.ConnectionDelay < 0 {
+		return fmt.Errorf("negative connection delay %s", opts.ConnectionDelay)
+	}
+	return opts.PacketFilter.Validate()
+}
+
+// PacketFilterPolicy determines how the channels of a PacketFilter are treated.
+type PacketFilterPolicy string
+
+const (
+	// NoPacketFilter relays packets on every channel of the path.
+	NoPacketFilter PacketFilterPolicy = ""
+
+	// PacketFilterAllowList relays packets only on the listed channels.
+	PacketFilterAllowList PacketFilterPolicy = "allowlist"
+
+	// PacketFilterDenyList relays packets on every channel except the listed ones.
+	PacketFilterDenyList PacketFilterPolicy = "denylist"
+)
+
+// PacketFilter restricts a relayer to a subset of the channels on a path.
+type PacketFilter struct {
+	Policy PacketFilterPolicy
+
+	// IDs of channels on the path's source chain.
+	ChannelIDs []string
+}
+
+// Validate will check that the specified PacketFilter is valid.
+func (f PacketFilter) Validate() error {
+	switch f.Policy {
+	case NoPacketFilter:
+		if len(f.ChannelIDs) > 0 {
+			return fmt.Errorf("packet filter has channels but no policy")
+		}
+	case PacketFilterAllowList, PacketFilterDenyList:
+		if len(f.ChannelIDs) == 0 {
+			return fmt.Errorf("packet filter %s has no channels", f.Policy)
+		}
+		for _, id := range f.ChannelIDs {
+			if err := host.ChannelIdentifierValidator(id); err != nil {
+				return fmt.Errorf("invalid channel in packet filter: %w", err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid packet filter policy %q", f.Policy)
+	}
+	return nil
+}
+
+// Allows reports whether the filter permits relaying packets on the channel
+// with the given ID on the path's source chain.
+func (f PacketFilter) Allows(channelID string) bool {
+	listed := false
+	for _, id := range f.ChannelIDs {
+		if id == channelID {
+			listed = true
+			break
+		}
+	}
+
+	switch f.Policy {
+	case PacketFilterAllowList:
+		return listed
+	case PacketFilterDenyList:
+		return !listed
+	default:
+		return true
+	}
+}
+
 // ExecReporter is the interface of a narrow type returned by testreporter.RelayerExecReporter.
 // This avoids a direct dependency on the testreporter package,
 // and it avoids the relayer needing to be aware of a *testing.T.
@@ -178,3 +334,23 @@ type RelayerExecReporter interface {
 		err error,
 	)
 }
+
+// RelayerLogReporter is an optional interface that a RelayerExecReporter may satisfy,
+// to track the output of a long-running relayer process line by line as it is produced.
+// The reporter returned by testreporter's RelayerExecReporter method satisfies this interface.
+type RelayerLogReporter interface {
+	TrackRelayerLog(
+		// The name of the docker container in which the relayer is running,
+		// or empty if it is not running in docker.
+		containerName string,
+
+		// The stream that produced the line, either "stdout" or "stderr".
+		stream string,
+
+		// When the line was produced.
+		when time.Time,
+
+		// The line of output, without a trailing newline.
+		line string,
+	)
+}
@@ -2,6 +2,7 @@ package ibc
 
 import (
 	"testing"
+	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
 	"github.com/stretchr/testify/require"
@@ -34,3 +35,35 @@ func TestChannelOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+func TestPathOptions(t *testing.T) {
+	// The zero value relays every channel.
+	var opts PathOptions
+	require.NoError(t, opts.Validate())
+	require.True(t, opts.PacketFilter.Allows("channel-0"))
+
+	allow := PacketFilter{Policy: PacketFilterAllowList, ChannelIDs: []string{"channel-1"}}
+	require.NoError(t, allow.Validate())
+	require.False(t, allow.Allows("channel-0"))
+	require.True(t, allow.Allows("channel-1"))
+
+	deny := PacketFilter{Policy: PacketFilterDenyList, ChannelIDs: []string{"channel-1"}}
+	require.NoError(t, deny.Validate())
+	require.True(t, deny.Allows("channel-0"))
+	require.False(t, deny.Allows("channel-1"))
+
+	// Channels without a policy.
+	require.Error(t, PacketFilter{ChannelIDs: []string{"channel-0"}}.Validate())
+
+	// A policy without channels.
+	require.Error(t, PacketFilter{Policy: PacketFilterAllowList}.Validate())
+
+	// Unknown policy.
+	require.Error(t, PacketFilter{Policy: "allow", ChannelIDs: []string{"channel-0"}}.Validate())
+
+	// Invalid channel identifier.
+	require.Error(t, PacketFilter{Policy: PacketFilterDenyList, ChannelIDs: []string{"not a channel"}}.Validate())
+
+	require.NoError(t, PathOptions{ConnectionDelay: 10 * time.Second}.Validate())
+	require.Error(t, PathOptions{ConnectionDelay: -time.Second}.Validate())
+}
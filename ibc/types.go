@@ -1,6 +1,11 @@
 package ibc
 
-import ibcexported "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+import (
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+)
 
 type ChainConfig struct {
 	Type           string
@@ -14,6 +19,53 @@ type ChainConfig struct {
 	GasAdjustment  float64
 	TrustingPeriod string
 	NoHostMount    bool
+
+	// GenesisModifications are applied, in order, to the genesis file of a cosmos chain
+	// after the genesis transactions are collected and before the file is copied to every node.
+	// Unlike ModifyGenesis, they can be set from a JSON matrix file.
+	GenesisModifications []GenesisKV
+
+	// ModifyGenesis, if set, is called with the genesis file of a cosmos chain,
+	// after GenesisModifications are applied, and returns the genesis file to start the chain with.
+	ModifyGenesis func(ChainConfig, []byte) ([]byte, error) `json:"-"`
+
+	// LocalImage, if set, is built from the local filesystem when a cosmos chain is initialized,
+	// and replaces the first of Images, in order to test changes to a chain that are not published to a registry.
+	LocalImage *LocalImageBuild
+
+	// SnapshotInterval, if non-zero, is the number of blocks between the snapshots of a cosmos chain's state
+	// taken by its validators, from which full nodes added through state sync bootstrap.
+	// Snapshots are disabled by default.
+	SnapshotInterval uint64
+}
+
+// LocalImageBuild describes a Docker image built from the local filesystem,
+// either from a Dockerfile or by layering a chain binary onto an existing image.
+// The image is tagged with a hash of the build's inputs, so it is only built again when they change.
+type LocalImageBuild struct {
+	// ContextDir is the directory sent to Docker as the context of a build from a Dockerfile,
+	// such as the root of a chain's repository.
+	ContextDir string
+
+	// Dockerfile is the path of the Dockerfile to build, relative to ContextDir.
+	// Defaults to "Dockerfile".
+	Dockerfile string
+
+	// Binary is the path of a chain binary, built for Linux, which is layered onto the first of the chain's Images
+	// in place of the chain's Bin. Binary is used instead of ContextDir and Dockerfile.
+	Binary string
+}
+
+// GenesisKV sets the value at a path in a genesis file.
+type GenesisKV struct {
+	// Key is the path of the value to set, as keys of JSON objects separated by dots,
+	// such as "app_state.gov.voting_params.voting_period".
+	// A key that is an integer indexes into a JSON array.
+	// Missing objects on the path are created.
+	Key string
+
+	// Value is the JSON value to set.
+	Value interface{}
 }
 
 func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
@@ -59,6 +111,23 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 
 	// Skip NoHostMount so that false can be distinguished.
 
+	if len(other.GenesisModifications) > 0 {
+		c.GenesisModifications = append([]GenesisKV(nil), other.GenesisModifications...)
+	}
+
+	if other.ModifyGenesis != nil {
+		c.ModifyGenesis = other.ModifyGenesis
+	}
+
+	if other.LocalImage != nil {
+		localImage := *other.LocalImage
+		c.LocalImage = &localImage
+	}
+
+	if other.SnapshotInterval != 0 {
+		c.SnapshotInterval = other.SnapshotInterval
+	}
+
 	return c
 }
 
@@ -138,6 +207,35 @@ type ConnectionOutput struct {
 
 type ConnectionOutputs []*ConnectionOutput
 
+// ClientOutput represents the IBC light client information queried from a chain's state for a particular client.
+type ClientOutput struct {
+	ID string
+
+	// The ID of the chain tracked by the client.
+	ChainID string
+
+	TrustingPeriod time.Duration
+	LatestHeight   clienttypes.Height
+
+	// Whether the client has been frozen, such as after misbehaviour was submitted.
+	Frozen bool
+}
+
+// ClientMisbehaviour is evidence of misbehaviour submitted to a light client in a transaction.
+type ClientMisbehaviour struct {
+	ClientID string
+
+	// The address of the account that submitted the misbehaviour.
+	Signer string
+}
+
+// PathEnd identifies the client and connection on one chain of an existing path.
+type PathEnd struct {
+	ChainID      string
+	ClientID     string
+	ConnectionID string
+}
+
 type RelayerWallet struct {
 	Mnemonic string `json:"mnemonic"`
 	Address  string `json:"address"`
@@ -148,4 +246,5 @@ type RelayerImplementation int64
 const (
 	CosmosRly RelayerImplementation = iota
 	Hermes
+	InProcess
 )
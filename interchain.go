@@ -3,6 +3,7 @@ package ibctest
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
@@ -11,6 +12,7 @@ import (
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/testreporter"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 )
 
 // Interchain represents a full IBC network, encompassing a collection of
@@ -27,6 +29,13 @@ type Interchain struct {
 	// Key: relayer and path name; Value: the two chains being linked.
 	links map[relayerPath][2]ibc.Chain
 
+	// Key: relayer and path name; Value: the options for generating the path.
+	pathOptions map[relayerPath]ibc.PathOptions
+
+	// Key: relayer and path name of a link that reuses another relayer's path;
+	// Value: the other relayer, which links the path.
+	sharedLinks map[relayerPath]ibc.Relayer
+
 	// Set to true after Build is called once.
 	built bool
 
@@ -36,6 +45,10 @@ type Interchain struct {
 
 	// Set during Build and cleaned up in the Close method.
 	cs *chainSet
+
+	// Set during Build when tracking relayer metrics, and used in Close.
+	metricsEg     *errgroup.Group
+	metricsCancel context.CancelFunc
 }
 
 // NewInterchain returns a new Interchain.
@@ -49,7 +62,9 @@ func NewInterchain() *Interchain {
 		chains:   make(map[ibc.Chain]string),
 		relayers: make(map[ibc.Relayer]string),
 
-		links: make(map[relayerPath][2]ibc.Chain),
+		links:       make(map[relayerPath][2]ibc.Chain),
+		pathOptions: make(map[relayerPath]ibc.PathOptions),
+		sharedLinks: make(map[relayerPath]ibc.Relayer),
 	}
 }
 
@@ -118,6 +133,18 @@ type InterchainLink struct {
 
 	// Name of path to create.
 	Path string
+
+	// Optional. If set, Relayer does not link a new path,
+	// but instead relays on the clients and connection created for the link
+	// between the same chains and with the same path name on the ShareWith relayer.
+	// That link must be added first, and Relayer must implement ibc.PathSharingRelayer.
+	//
+	// This is useful for testing multiple relayers competing to relay the same channels.
+	ShareWith ibc.Relayer
+
+	// Optional. Options for generating the path, such as a packet filter.
+	// Must be the zero value if ShareWith is set, as a shared path is not generated by Relayer.
+	PathOptions ibc.PathOptions
 }
 
 // AddLink adds the given link to the Interchain.
@@ -139,6 +166,17 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		panic(fmt.Errorf("chains must be different (both were %v)", link.Chain1))
 	}
 
+	if link.ShareWith == link.Relayer {
+		panic(fmt.Errorf("relayer %v cannot share path %q with itself", link.Relayer, link.Path))
+	}
+
+	if err := link.PathOptions.Validate(); err != nil {
+		panic(fmt.Errorf("invalid options for path %q: %w", link.Path, err))
+	}
+	if link.ShareWith != nil && !reflect.DeepEqual(link.PathOptions, ibc.PathOptions{}) {
+		panic(fmt.Errorf("path %q shared with relayer %v cannot have its own options", link.Path, link.ShareWith))
+	}
+
 	key := relayerPath{
 		Relayer: link.Relayer,
 		Path:    link.Path,
@@ -148,7 +186,27 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
 	}
 
+	if link.ShareWith != nil {
+		sharedKey := relayerPath{
+			Relayer: link.ShareWith,
+			Path:    link.Path,
+		}
+		chains, exists := ic.links[sharedKey]
+		if !exists {
+			panic(fmt.Errorf("relayer %v has no path named %q to share", link.ShareWith, link.Path))
+		}
+		if _, shared := ic.sharedLinks[sharedKey]; shared {
+			panic(fmt.Errorf("path %q on relayer %v is itself shared; share with the relayer that links it", link.Path, link.ShareWith))
+		}
+		if chains != [2]ibc.Chain{link.Chain1, link.Chain2} && chains != [2]ibc.Chain{link.Chain2, link.Chain1} {
+			panic(fmt.Errorf("path %q on relayer %v is between different chains", link.Path, link.ShareWith))
+		}
+
+		ic.sharedLinks[key] = link.ShareWith
+	}
+
 	ic.links[key] = [2]ibc.Chain{link.Chain1, link.Chain2}
+	ic.pathOptions[key] = link.PathOptions
 	return ic
 }
 
@@ -214,6 +272,10 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return fmt.Errorf("failed to track blocks: %w", err)
 	}
 
+	if err := ic.trackRelayerMetrics(ctx); err != nil {
+		return fmt.Errorf("failed to track relayer metrics: %w", err)
+	}
+
 	if err := ic.configureRelayerKeys(ctx, rep); err != nil {
 		// Error already wrapped with appropriate detail.
 		return err
@@ -236,28 +298,119 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 		return err
 	}
 
+	// Ends of the linked paths that other relayers share.
+	sharedPathEnds := make(map[relayerPath][2]ibc.PathEnd)
+
 	// For every relayer link, teach the relayer about the link and create the link.
 	for rp, chains := range ic.links {
+		if _, shared := ic.sharedLinks[rp]; shared {
+			// Created below, once the shared path is linked.
+			continue
+		}
+
 		c0 := chains[0]
 		c1 := chains[1]
-		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path); err != nil {
+		if err := rp.Relayer.GeneratePath(ctx, rep, c0.Config().ChainID, c1.Config().ChainID, rp.Path, ic.pathOptions[rp]); err != nil {
 			return fmt.Errorf(
 				"failed to generate path %s on relayer %s between chains %s and %s: %w",
 				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
 			)
 		}
 
+		var connsBefore ibc.ConnectionOutputs
+		isShared := ic.isSharedPath(rp)
+		if isShared {
+			connsBefore, err = rp.Relayer.GetConnections(ctx, rep, c0.Config().ChainID)
+			if err != nil {
+				return fmt.Errorf("failed to get connections on chain %s before linking path %s: %w", ic.chains[c0], rp.Path, err)
+			}
+		}
+
 		if err := rp.Relayer.LinkPath(ctx, rep, rp.Path, opts.CreateChannelOpts); err != nil {
 			return fmt.Errorf(
 				"failed to link path %s on relayer %s between chains %s and %s: %w",
 				rp.Path, rp.Relayer, ic.chains[c0], ic.chains[c1], err,
 			)
 		}
+
+		if isShared {
+			ends, err := linkedPathEnds(ctx, rep, rp.Relayer, c0, c1, connsBefore)
+			if err != nil {
+				return fmt.Errorf("failed to find ends of path %s on relayer %s: %w", rp.Path, rp.Relayer, err)
+			}
+			sharedPathEnds[rp] = ends
+		}
+	}
+
+	for rp, shareWith := range ic.sharedLinks {
+		sharedRP := relayerPath{Relayer: shareWith, Path: rp.Path}
+		ends := sharedPathEnds[sharedRP]
+		if ic.links[rp][0] != ic.links[sharedRP][0] {
+			// The link declared its chains in the opposite order of the shared link.
+			ends[0], ends[1] = ends[1], ends[0]
+		}
+
+		r, ok := rp.Relayer.(ibc.PathSharingRelayer)
+		if !ok {
+			return fmt.Errorf("relayer %s cannot share path %s: it does not implement ibc.PathSharingRelayer", ic.relayers[rp.Relayer], rp.Path)
+		}
+		if err := r.GenerateSharedPath(ctx, rep, rp.Path, ends[0], ends[1]); err != nil {
+			return fmt.Errorf(
+				"failed to generate shared path %s on relayer %s between chains %s and %s: %w",
+				rp.Path, ic.relayers[rp.Relayer], ends[0].ChainID, ends[1].ChainID, err,
+			)
+		}
 	}
 
 	return nil
 }
 
+// isSharedPath reports whether another link shares the path of the given link.
+func (ic *Interchain) isSharedPath(rp relayerPath) bool {
+	for sharing, shareWith := range ic.sharedLinks {
+		if shareWith == rp.Relayer && sharing.Path == rp.Path {
+			return true
+		}
+	}
+	return false
+}
+
+// linkedPathEnds returns the ends of the path that r just linked between c0 and c1,
+// by finding the single connection on c0 that is absent from connsBefore.
+func linkedPathEnds(
+	ctx context.Context,
+	rep *testreporter.RelayerExecReporter,
+	r ibc.Relayer,
+	c0, c1 ibc.Chain,
+	connsBefore ibc.ConnectionOutputs,
+) ([2]ibc.PathEnd, error) {
+	before := make(map[string]bool, len(connsBefore))
+	for _, conn := range connsBefore {
+		before[conn.ID] = true
+	}
+
+	connsAfter, err := r.GetConnections(ctx, rep, c0.Config().ChainID)
+	if err != nil {
+		return [2]ibc.PathEnd{}, err
+	}
+
+	var linked []*ibc.ConnectionOutput
+	for _, conn := range connsAfter {
+		if !before[conn.ID] {
+			linked = append(linked, conn)
+		}
+	}
+	if len(linked) != 1 {
+		return [2]ibc.PathEnd{}, fmt.Errorf("expected 1 new connection on chain %s, found %d", c0.Config().ChainID, len(linked))
+	}
+
+	conn := linked[0]
+	return [2]ibc.PathEnd{
+		{ChainID: c0.Config().ChainID, ClientID: conn.ClientID, ConnectionID: conn.ID},
+		{ChainID: c1.Config().ChainID, ClientID: conn.Counterparty.ClientId, ConnectionID: conn.Counterparty.ConnectionId},
+	}, nil
+}
+
 // WithLog sets the logger on the interchain object.
 // Usually the default nop logger is fine, but sometimes it can be helpful
 // to see more verbose logs, typically by passing zaptest.NewLogger(t).
@@ -269,6 +422,13 @@ func (ic *Interchain) WithLog(log *zap.Logger) *Interchain {
 // Close cleans up any resources created during Build,
 // and returns any relevant errors.
 func (ic *Interchain) Close() error {
+	// Relayer metrics are saved to the database closed by the chain set,
+	// so stop tracking them first.
+	if ic.metricsCancel != nil {
+		ic.metricsCancel()
+		_ = ic.metricsEg.Wait()
+	}
+
 	return ic.cs.Close()
 }
 
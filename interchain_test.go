@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/ibctest"
+	"github.com/strangelove-ventures/ibctest/chain/mock"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/relayer/rly"
+	"github.com/strangelove-ventures/ibctest/test"
 	"github.com/strangelove-ventures/ibctest/testreporter"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -321,6 +324,53 @@ func TestInterchain_ConflictRejection(t *testing.T) {
 			_ = ibctest.NewInterchain().AddRelayer(&r1, "r").AddRelayer(&r2, "r")
 		})
 	})
+
+	t.Run("shared path", func(t *testing.T) {
+		cf := ibctest.NewBuiltinChainFactory(zap.NewNop(), []*ibctest.ChainSpec{
+			{Name: "gaia", ChainName: "g1", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-0"}},
+			{Name: "gaia", ChainName: "g2", Version: "v7.0.1", ChainConfig: ibc.ChainConfig{ChainID: "cosmoshub-1"}},
+		})
+
+		chains, err := cf.Chains(t.Name())
+		require.NoError(t, err)
+		c0, c1 := chains[0], chains[1]
+
+		var r1, r2 rly.CosmosRelayer
+		newInterchain := func() *ibctest.Interchain {
+			return ibctest.NewInterchain().AddChain(c0).AddChain(c1).AddRelayer(&r1, "r1").AddRelayer(&r2, "r2")
+		}
+
+		exp := fmt.Sprintf("relayer %v has no path named %q to share", &r1, "p")
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain().AddLink(ibctest.InterchainLink{
+				Chain1: c0, Chain2: c1, Relayer: &r2, Path: "p", ShareWith: &r1,
+			})
+		})
+
+		exp = fmt.Sprintf("relayer %v cannot share path %q with itself", &r1, "p")
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain().
+				AddLink(ibctest.InterchainLink{Chain1: c0, Chain2: c1, Relayer: &r1, Path: "p"}).
+				AddLink(ibctest.InterchainLink{Chain1: c0, Chain2: c1, Relayer: &r1, Path: "p", ShareWith: &r1})
+		})
+
+		exp = fmt.Sprintf("path %q shared with relayer %v cannot have its own options", "p", &r1)
+		require.PanicsWithError(t, exp, func() {
+			_ = newInterchain().
+				AddLink(ibctest.InterchainLink{Chain1: c0, Chain2: c1, Relayer: &r1, Path: "p"}).
+				AddLink(ibctest.InterchainLink{
+					Chain1: c0, Chain2: c1, Relayer: &r2, Path: "p", ShareWith: &r1,
+					PathOptions: ibc.PathOptions{ConnectionDelay: time.Second},
+				})
+		})
+
+		// Sharing is allowed with the chains in either order.
+		require.NotPanics(t, func() {
+			_ = newInterchain().
+				AddLink(ibctest.InterchainLink{Chain1: c0, Chain2: c1, Relayer: &r1, Path: "p"}).
+				AddLink(ibctest.InterchainLink{Chain1: c1, Chain2: c0, Relayer: &r2, Path: "p", ShareWith: &r1})
+		})
+	})
 }
 
 func TestInterchain_AddNil(t *testing.T) {
@@ -332,3 +382,141 @@ func TestInterchain_AddNil(t *testing.T) {
 		_ = ibctest.NewInterchain().AddRelayer(nil, "r")
 	})
 }
+
+// TestInterchain_Mock builds an interchain of mock chains,
+// so that the orchestration in Build is exercised without Docker.
+func TestInterchain_Mock(t *testing.T) {
+	t.Parallel()
+
+	c0 := mock.NewChain(ibc.ChainConfig{ChainID: "mock-0", Bech32Prefix: "cosmos", Denom: "uatom"})
+	c1 := mock.NewChain(ibc.ChainConfig{ChainID: "mock-1", Bech32Prefix: "osmo", Denom: "uosmo"})
+	r0 := mock.NewRelayer(c0, c1)
+	r1 := mock.NewRelayer(c0, c1)
+
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r0, "r0").
+		AddRelayer(r1, "r1").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r0,
+			Path:    pathName,
+		}).
+		AddLink(ibctest.InterchainLink{
+			Chain1:    c0,
+			Chain2:    c1,
+			Relayer:   r1,
+			Path:      pathName,
+			ShareWith: r0,
+		})
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+
+	t.Run("relayer wallets are funded", func(t *testing.T) {
+		for _, r := range []ibc.Relayer{r0, r1} {
+			for _, c := range []ibc.Chain{c0, c1} {
+				w, ok := r.GetWallet(c.Config().ChainID)
+				require.True(t, ok)
+
+				bal, err := c.GetBalance(ctx, w.Address, c.Config().Denom)
+				require.NoError(t, err)
+				require.Positive(t, bal)
+			}
+		}
+	})
+
+	// Only the relayer that does not link the path is started, so packets must be relayed on the shared path.
+	require.NoError(t, r1.StartRelayer(ctx, eRep, pathName))
+	defer func() { require.NoError(t, r1.StopRelayer(ctx, eRep)) }()
+
+	t.Run("transfer on shared path", func(t *testing.T) {
+		users := ibctest.GetAndFundTestUsers(t, ctx, "user", 10000, c0, c1)
+
+		channels, err := r1.GetChannels(ctx, eRep, c0.Config().ChainID)
+		require.NoError(t, err)
+		require.Len(t, channels, 1)
+
+		tx, err := c0.SendIBCTransfer(ctx, channels[0].ChannelID, users[0].KeyName, ibc.WalletAmount{
+			Address: users[1].Bech32Address(c1.Config().Bech32Prefix),
+			Denom:   c0.Config().Denom,
+			Amount:  1000,
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, tx.Validate())
+
+		_, err = test.PollForAck(ctx, c0, tx.Height, tx.Height+10, tx.Packet)
+		require.NoError(t, err)
+
+		bal, err := c0.GetBalance(ctx, users[0].Bech32Address(c0.Config().Bech32Prefix), c0.Config().Denom)
+		require.NoError(t, err)
+		require.Equal(t, int64(9000), bal)
+	})
+}
+
+func TestInterchain_PathOptions(t *testing.T) {
+	t.Parallel()
+
+	c0 := mock.NewChain(ibc.ChainConfig{ChainID: "mock-0", Bech32Prefix: "cosmos", Denom: "uatom"})
+	c1 := mock.NewChain(ibc.ChainConfig{ChainID: "mock-1", Bech32Prefix: "osmo", Denom: "uosmo"})
+	r := mock.NewRelayer(c0, c1)
+
+	// Building the interchain creates channel-0, so only a second channel is relayed.
+	const pathName = "p"
+	ic := ibctest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(ibctest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+			Path:    pathName,
+			PathOptions: ibc.PathOptions{
+				PacketFilter: ibc.PacketFilter{Policy: ibc.PacketFilterAllowList, ChannelIDs: []string{"channel-1"}},
+			},
+		})
+	defer ic.Close()
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	ctx := context.Background()
+	require.NoError(t, ic.Build(ctx, eRep, ibctest.InterchainBuildOptions{
+		TestName:          t.Name(),
+		CreateChannelOpts: ibc.DefaultChannelOpts(),
+	}))
+	require.NoError(t, r.CreateChannel(ctx, eRep, pathName, ibc.DefaultChannelOpts()))
+
+	require.NoError(t, r.StartRelayer(ctx, eRep, pathName))
+	defer func() { require.NoError(t, r.StopRelayer(ctx, eRep)) }()
+
+	users := ibctest.GetAndFundTestUsers(t, ctx, "user", 10000, c0, c1)
+	sendTransfer := func(channelID string) ibc.Tx {
+		tx, err := c0.SendIBCTransfer(ctx, channelID, users[0].KeyName, ibc.WalletAmount{
+			Address: users[1].Bech32Address(c1.Config().Bech32Prefix),
+			Denom:   c0.Config().Denom,
+			Amount:  1000,
+		}, nil)
+		require.NoError(t, err)
+		require.NoError(t, tx.Validate())
+		return tx
+	}
+	deniedTx := sendTransfer("channel-0")
+	allowedTx := sendTransfer("channel-1")
+
+	_, err := test.PollForAck(ctx, c0, allowedTx.Height, allowedTx.Height+10, allowedTx.Packet)
+	require.NoError(t, err)
+	_, err = test.PollForAck(ctx, c0, deniedTx.Height, deniedTx.Height+10, deniedTx.Packet)
+	require.ErrorIs(t, err, test.ErrNotFound)
+}
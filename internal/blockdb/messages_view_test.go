@@ -91,7 +91,7 @@ func TestMessagesView(t *testing.T) {
 	// Generate the path.
 	// No transactions happen here.
 	const pathName = "p"
-	require.NoError(t, r.GeneratePath(ctx, eRep, gaia0ChainID, gaia1ChainID, pathName))
+	require.NoError(t, r.GeneratePath(ctx, eRep, gaia0ChainID, gaia1ChainID, pathName, ibc.PathOptions{}))
 
 	t.Run("create clients", func(t *testing.T) {
 		// Creating the clients will cause transactions.
@@ -287,17 +287,22 @@ WHERE type = "/ibc.applications.transfer.v1.MsgTransfer" AND chain_id = ?
 		require.NoError(t, r.FlushPackets(ctx, eRep, pathName, gaia0ChannelID))
 
 		const qMsgRecvPacket = `SELECT
-port_id, channel_id, counterparty_port_id, counterparty_channel_id
+port_id, channel_id, counterparty_port_id, counterparty_channel_id, signer
 FROM v_cosmos_messages
 WHERE type = "/ibc.core.channel.v1.MsgRecvPacket" AND chain_id = ?
 `
-		var portID, channelID, counterpartyPortID, counterpartyChannelID string
-		require.NoError(t, db.QueryRow(qMsgRecvPacket, gaia1ChainID).Scan(&portID, &channelID, &counterpartyPortID, &counterpartyChannelID))
+		var portID, channelID, counterpartyPortID, counterpartyChannelID, signer string
+		require.NoError(t, db.QueryRow(qMsgRecvPacket, gaia1ChainID).Scan(&portID, &channelID, &counterpartyPortID, &counterpartyChannelID, &signer))
 
 		require.Equal(t, portID, gaia0Port)
 		require.Equal(t, channelID, gaia0ChannelID)
 		require.Equal(t, counterpartyPortID, gaia1Port)
 		require.Equal(t, counterpartyChannelID, gaia1ChannelID)
+
+		// The packet is received by the relayer's wallet on the destination chain.
+		wallet, ok := r.GetWallet(gaia1ChainID)
+		require.True(t, ok)
+		require.Equal(t, wallet.Address, signer)
 	})
 	if t.Failed() {
 		return
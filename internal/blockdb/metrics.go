@@ -0,0 +1,169 @@
+package blockdb
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+// MetricSample is the value of a single Prometheus time series at the time it was scraped.
+type MetricSample struct {
+	Name   string
+	Labels map[string]string
+	Value  float64
+}
+
+// ParseMetrics parses metrics in the Prometheus text exposition format into samples.
+// Histograms and summaries are flattened into their conventional
+// _bucket, _sum, and _count series, and quantile series, respectively.
+// Samples are sorted by name, and samples with non-finite values are omitted.
+func ParseMetrics(r io.Reader) ([]MetricSample, error) {
+	var p expfmt.TextParser
+	families, err := p.TextToMetricFamilies(r)
+	if err != nil {
+		return nil, fmt.Errorf("parse metrics: %w", err)
+	}
+
+	names := make([]string, 0, len(families))
+	for name := range families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var samples []MetricSample
+	add := func(name string, labels map[string]string, value float64) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			// SQLite cannot store these values as REAL.
+			return
+		}
+		samples = append(samples, MetricSample{Name: name, Labels: labels, Value: value})
+	}
+
+	for _, name := range names {
+		for _, m := range families[name].Metric {
+			labels := make(map[string]string, len(m.Label))
+			for _, l := range m.Label {
+				labels[l.GetName()] = l.GetValue()
+			}
+
+			switch {
+			case m.Counter != nil:
+				add(name, labels, m.Counter.GetValue())
+			case m.Gauge != nil:
+				add(name, labels, m.Gauge.GetValue())
+			case m.Untyped != nil:
+				add(name, labels, m.Untyped.GetValue())
+			case m.Histogram != nil:
+				for _, b := range m.Histogram.Bucket {
+					add(name+"_bucket", withLabel(labels, "le", formatFloat(b.GetUpperBound())), float64(b.GetCumulativeCount()))
+				}
+				add(name+"_sum", labels, m.Histogram.GetSampleSum())
+				add(name+"_count", labels, float64(m.Histogram.GetSampleCount()))
+			case m.Summary != nil:
+				for _, q := range m.Summary.Quantile {
+					add(name, withLabel(labels, "quantile", formatFloat(q.GetQuantile())), q.GetValue())
+				}
+				add(name+"_sum", labels, m.Summary.GetSampleSum())
+				add(name+"_count", labels, float64(m.Summary.GetSampleCount()))
+			}
+		}
+	}
+
+	return samples, nil
+}
+
+// withLabel returns a copy of labels with the additional label k set to v.
+func withLabel(labels map[string]string, k, v string) map[string]string {
+	out := make(map[string]string, len(labels)+1)
+	for lk, lv := range labels {
+		out[lk] = lv
+	}
+	out[k] = v
+	return out
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// MetricsScraper scrapes the current values of metrics.
+type MetricsScraper interface {
+	// ScrapeMetrics returns the current samples.
+	// An empty result with a nil error indicates that no metrics are currently available.
+	ScrapeMetrics(ctx context.Context) ([]MetricSample, error)
+}
+
+// MetricsSaver saves the samples of metrics scraped at a point in time.
+type MetricsSaver interface {
+	SaveMetrics(ctx context.Context, scrapedAt time.Time, samples []MetricSample) error
+}
+
+// MetricsCollector saves scraped metrics at regular intervals.
+type MetricsCollector struct {
+	scraper MetricsScraper
+	log     *zap.Logger
+	rate    time.Duration
+	saver   MetricsSaver
+	cancel  context.CancelFunc
+}
+
+// NewMetricsCollector creates a valid MetricsCollector that scrapes every duration at rate.
+func NewMetricsCollector(log *zap.Logger, scraper MetricsScraper, saver MetricsSaver, rate time.Duration) *MetricsCollector {
+	return &MetricsCollector{
+		scraper: scraper,
+		log:     log,
+		rate:    rate,
+		saver:   saver,
+	}
+}
+
+// Collect scrapes and saves metrics until ctx is canceled or Stop is called.
+// Failures are logged and retried at the next interval,
+// as the metrics are expected to be unavailable at times, e.g. while the relayer is stopped.
+func (c *MetricsCollector) Collect(ctx context.Context) {
+	ctx, c.cancel = context.WithCancel(ctx)
+	defer c.cancel()
+
+	tick := time.NewTicker(c.rate)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick.C:
+			if err := c.collect(ctx); err != nil && ctx.Err() == nil {
+				c.log.Debug("Failed to collect metrics", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Stop terminates the Collect loop.
+// Stop is safe to be called concurrently and is safe to be called multiple times.
+//
+// If Collect has not been called, Stop panics.
+func (c *MetricsCollector) Stop() {
+	c.cancel()
+}
+
+func (c *MetricsCollector) collect(ctx context.Context) error {
+	scrapedAt := time.Now()
+	samples, err := c.scraper.ScrapeMetrics(ctx)
+	if err != nil {
+		return fmt.Errorf("scrape metrics: %w", err)
+	}
+	if len(samples) == 0 {
+		return nil
+	}
+	if err := c.saver.SaveMetrics(ctx, scrapedAt, samples); err != nil {
+		return fmt.Errorf("save metrics: %w", err)
+	}
+	return nil
+}
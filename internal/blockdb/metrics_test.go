@@ -0,0 +1,99 @@
+package blockdb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseMetrics(t *testing.T) {
+	const text = `# HELP relayer_packets_relayed Number of packets relayed.
+# TYPE relayer_packets_relayed counter
+relayer_packets_relayed{chain="chain-a",channel="channel-0"} 3
+# TYPE relayer_wallet_balance gauge
+relayer_wallet_balance{chain="chain-a"} 1.5e+06
+# TYPE relayer_tx_latency_seconds histogram
+relayer_tx_latency_seconds_bucket{le="0.5"} 1
+relayer_tx_latency_seconds_bucket{le="+Inf"} 2
+relayer_tx_latency_seconds_sum 1.25
+relayer_tx_latency_seconds_count 2
+# TYPE relayer_errors untyped
+relayer_errors NaN
+`
+
+	samples, err := ParseMetrics(strings.NewReader(text))
+	require.NoError(t, err)
+
+	require.Equal(t, []MetricSample{
+		{Name: "relayer_packets_relayed", Labels: map[string]string{"chain": "chain-a", "channel": "channel-0"}, Value: 3},
+		{Name: "relayer_tx_latency_seconds_bucket", Labels: map[string]string{"le": "0.5"}, Value: 1},
+		{Name: "relayer_tx_latency_seconds_bucket", Labels: map[string]string{"le": "+Inf"}, Value: 2},
+		{Name: "relayer_tx_latency_seconds_sum", Labels: map[string]string{}, Value: 1.25},
+		{Name: "relayer_tx_latency_seconds_count", Labels: map[string]string{}, Value: 2},
+		{Name: "relayer_wallet_balance", Labels: map[string]string{"chain": "chain-a"}, Value: 1.5e6},
+	}, samples)
+
+	_, err = ParseMetrics(strings.NewReader("not metrics {"))
+	require.Error(t, err)
+}
+
+type mockMetricsScraper func(ctx context.Context) ([]MetricSample, error)
+
+func (f mockMetricsScraper) ScrapeMetrics(ctx context.Context) ([]MetricSample, error) {
+	return f(ctx)
+}
+
+type mockMetricsSaver func(ctx context.Context, scrapedAt time.Time, samples []MetricSample) error
+
+func (f mockMetricsSaver) SaveMetrics(ctx context.Context, scrapedAt time.Time, samples []MetricSample) error {
+	return f(ctx, scrapedAt, samples)
+}
+
+func TestMetricsCollector_Collect(t *testing.T) {
+	var scrapes int64
+	scraper := mockMetricsScraper(func(ctx context.Context) ([]MetricSample, error) {
+		switch atomic.AddInt64(&scrapes, 1) {
+		case 1:
+			return nil, errors.New("connection refused")
+		case 2:
+			// Metrics not available yet.
+			return nil, nil
+		default:
+			return []MetricSample{{Name: "m", Value: 1}}, nil
+		}
+	})
+
+	saved := make(chan []MetricSample, 1)
+	saver := mockMetricsSaver(func(ctx context.Context, scrapedAt time.Time, samples []MetricSample) error {
+		select {
+		case saved <- samples:
+		default:
+		}
+		return nil
+	})
+
+	collector := NewMetricsCollector(zap.NewNop(), scraper, saver, time.Millisecond)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		collector.Collect(context.Background())
+	}()
+
+	select {
+	case samples := <-saved:
+		require.Equal(t, []MetricSample{{Name: "m", Value: 1}}, samples)
+		require.GreaterOrEqual(t, atomic.LoadInt64(&scrapes), int64(3))
+	case <-time.After(10 * time.Second):
+		t.Fatal("metrics were never saved")
+	}
+
+	collector.Stop()
+	<-done
+}
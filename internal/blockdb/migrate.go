@@ -146,6 +146,30 @@ ON CONFLICT(git_sha) DO UPDATE SET git_sha=git_sha`, nowRFC3339(), gitSha)
 		return fmt.Errorf("create table tendermint_event: %w", err)
 	}
 
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS relayer (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL CHECK ( length(name) > 0 ),
+    fk_test_id INTEGER,
+    FOREIGN KEY(fk_test_id) REFERENCES test_case(id) ON DELETE CASCADE,
+    UNIQUE(name,fk_test_id)
+)`)
+	if err != nil {
+		return fmt.Errorf("create table relayer: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE TABLE IF NOT EXISTS relayer_metric (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    scraped_at TEXT NOT NULL CHECK (length(scraped_at) > 0),
+    name TEXT NOT NULL CHECK (length(name) > 0),
+    labels TEXT NOT NULL, -- JSON object of label names to values
+    value REAL NOT NULL,
+    fk_relayer_id INTEGER,
+    FOREIGN KEY(fk_relayer_id) REFERENCES relayer(id) ON DELETE CASCADE
+)`)
+	if err != nil {
+		return fmt.Errorf("create table relayer_metric: %w", err)
+	}
+
 	// Creating views should be last migration step.
 	if err := upsertViews(tx); err != nil {
 		// Error already wrapped.
@@ -234,6 +258,7 @@ SELECT
       json_extract(value, "$.channel.counterparty.channel_id"), -- ChannelOpenTry
       json_extract(value, "$.packet.destination_channel")       -- MsgRecvPacket and MsgAcknowledgement (might be backwards)
     ) as counterparty_channel_id
+  , json_extract(value, "$.signer") as signer -- Account that submitted IBC core messages, e.g. a relayer's wallet
   , value as raw
 FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 `)
@@ -241,6 +266,30 @@ FROM v_tx_flattened, json_each(v_tx_flattened.tx, "$.body.messages")
 		return fmt.Errorf("create v_cosmos_messages view: %w", err)
 	}
 
+	_, err = tx.Exec(`DROP VIEW IF EXISTS v_relayer_metrics`)
+	if err != nil {
+		return fmt.Errorf("drop old v_relayer_metrics view: %w", err)
+	}
+
+	_, err = tx.Exec(`CREATE VIEW v_relayer_metrics AS
+SELECT
+  test_case.id as test_case_id
+  , test_case.created_at as test_case_created_at
+  , test_case.name as test_case_name
+  , relayer.id as relayer_kid
+  , relayer.name as relayer_name
+  , relayer_metric.scraped_at as scraped_at
+  , relayer_metric.name as name
+  , relayer_metric.labels as labels
+  , relayer_metric.value as value
+FROM relayer_metric
+LEFT JOIN relayer ON relayer_metric.fk_relayer_id = relayer.id
+LEFT JOIN test_case ON relayer.fk_test_id = test_case.id
+`)
+	if err != nil {
+		return fmt.Errorf("create v_relayer_metrics view: %w", err)
+	}
+
 	_, err = tx.Exec(`DROP VIEW IF EXISTS v_tx_agg`)
 	if err != nil {
 		return fmt.Errorf("drop old v_tx_agg view: %w", err)
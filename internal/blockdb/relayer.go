@@ -0,0 +1,44 @@
+package blockdb
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Relayer tracks the metrics scraped from a relayer.
+type Relayer struct {
+	db *sql.DB
+	id int64
+}
+
+// SaveMetrics tracks the samples of the relayer's metrics scraped at scrapedAt.
+func (r *Relayer) SaveMetrics(ctx context.Context, scrapedAt time.Time, samples []MetricSample) error {
+	dbTx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dbTx.Rollback() }()
+
+	at := scrapedAt.UTC().Format(time.RFC3339)
+	for _, s := range samples {
+		labels := s.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labelsJSON, err := json.Marshal(labels)
+		if err != nil {
+			return fmt.Errorf("marshal labels: %w", err)
+		}
+
+		_, err = dbTx.ExecContext(ctx, `INSERT INTO relayer_metric(scraped_at, name, labels, value, fk_relayer_id) VALUES (?, ?, ?, ?, ?)`,
+			at, s.Name, string(labelsJSON), s.Value, r.id)
+		if err != nil {
+			return fmt.Errorf("insert into relayer_metric: %w", err)
+		}
+	}
+
+	return dbTx.Commit()
+}
@@ -0,0 +1,64 @@
+package blockdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelayer_SaveMetrics(t *testing.T) {
+	t.Parallel()
+
+	db := migratedDB()
+	defer db.Close()
+
+	ctx := context.Background()
+
+	tc, err := CreateTestCase(ctx, db, "mytest", "abc123")
+	require.NoError(t, err)
+
+	r, err := tc.AddRelayer(ctx, "rly")
+	require.NoError(t, err)
+
+	_, err = tc.AddRelayer(ctx, "rly")
+	require.Error(t, err, "relayer names must be unique per test case")
+
+	scrapedAt := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, r.SaveMetrics(ctx, scrapedAt, []MetricSample{
+		{Name: "relayer_packets_relayed", Labels: map[string]string{"channel": "channel-0"}, Value: 3},
+		{Name: "relayer_errors", Value: 0},
+	}))
+
+	rows, err := db.Query(`SELECT
+  test_case_id, test_case_name, relayer_kid, relayer_name, scraped_at, name, labels, value
+FROM v_relayer_metrics
+ORDER BY name
+`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	type row struct {
+		TestCaseID   int64
+		TestCaseName string
+		RelayerKID   int64
+		RelayerName  string
+		ScrapedAt    string
+		Name         string
+		Labels       string
+		Value        float64
+	}
+	var got []row
+	for rows.Next() {
+		var r row
+		require.NoError(t, rows.Scan(&r.TestCaseID, &r.TestCaseName, &r.RelayerKID, &r.RelayerName, &r.ScrapedAt, &r.Name, &r.Labels, &r.Value))
+		got = append(got, r)
+	}
+	require.NoError(t, rows.Err())
+
+	require.Equal(t, []row{
+		{TestCaseID: tc.id, TestCaseName: "mytest", RelayerKID: r.id, RelayerName: "rly", ScrapedAt: "2022-06-01T12:00:00Z", Name: "relayer_errors", Labels: "{}", Value: 0},
+		{TestCaseID: tc.id, TestCaseName: "mytest", RelayerKID: r.id, RelayerName: "rly", ScrapedAt: "2022-06-01T12:00:00Z", Name: "relayer_packets_relayed", Labels: `{"channel":"channel-0"}`, Value: 3},
+	}, got)
+}
@@ -44,3 +44,20 @@ func (tc *TestCase) AddChain(ctx context.Context, chainID, chainType string) (*C
 		db: tc.db,
 	}, nil
 }
+
+// AddRelayer tracks and attaches a relayer to the test case.
+// The name must be unique per test case.
+func (tc *TestCase) AddRelayer(ctx context.Context, name string) (*Relayer, error) {
+	res, err := tc.db.ExecContext(ctx, `INSERT INTO relayer(name, fk_test_id) VALUES(?, ?)`, name, tc.id)
+	if err != nil {
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		id: id,
+		db: tc.db,
+	}, nil
+}
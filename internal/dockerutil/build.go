@@ -0,0 +1,191 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/errdefs"
+)
+
+// BuildImage builds an image from the Dockerfile at the path dockerfile, relative to the build context contextDir,
+// and tags it in repository with a hash of the build context and the Dockerfile's path.
+// If an image with that tag already exists, it is not built again.
+// BuildImage returns the tag of the image.
+func BuildImage(ctx context.Context, cli *client.Client, repository, contextDir, dockerfile string) (string, error) {
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+
+	h := sha256.New()
+	_, _ = io.WriteString(h, dockerfile+"\x00")
+	if err := writeTar(h, contextDir); err != nil {
+		return "", fmt.Errorf("hash build context %s: %w", contextDir, err)
+	}
+	tag := "local-" + hex.EncodeToString(h.Sum(nil))[:16]
+	ref := repository + ":" + tag
+
+	if _, _, err := cli.ImageInspectWithRaw(ctx, ref); err == nil {
+		return tag, nil
+	} else if !errdefs.IsNotFound(err) {
+		return "", fmt.Errorf("inspect image %s: %w", ref, err)
+	}
+
+	// Stream the build context rather than holding it in memory, as it may be the whole repository of a chain.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(writeTar(pw, contextDir))
+	}()
+	defer pr.Close()
+
+	res, err := cli.ImageBuild(ctx, pr, types.ImageBuildOptions{
+		Tags:        []string{ref},
+		Dockerfile:  dockerfile,
+		Remove:      true,
+		ForceRemove: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("build image %s: %w", ref, err)
+	}
+	defer res.Body.Close()
+
+	if err := readBuildOutput(res.Body); err != nil {
+		return "", fmt.Errorf("build image %s: %w", ref, err)
+	}
+	return tag, nil
+}
+
+// BuildBinaryImage builds an image that layers the executable at binaryPath onto baseImage, named bin,
+// in the first directory of baseImage's PATH so that it takes precedence over any existing executable of the same name.
+// baseImage is pulled if it does not exist locally.
+// The image is tagged as by BuildImage, and BuildBinaryImage returns the tag of the image.
+func BuildBinaryImage(ctx context.Context, cli *client.Client, repository, baseImage, binaryPath, bin string) (string, error) {
+	base, _, err := cli.ImageInspectWithRaw(ctx, baseImage)
+	if errdefs.IsNotFound(err) {
+		rc, pullErr := cli.ImagePull(ctx, baseImage, types.ImagePullOptions{})
+		if pullErr != nil {
+			return "", fmt.Errorf("pull image %s: %w", baseImage, pullErr)
+		}
+		_, _ = io.Copy(io.Discard, rc)
+		_ = rc.Close()
+
+		base, _, err = cli.ImageInspectWithRaw(ctx, baseImage)
+	}
+	if err != nil {
+		return "", fmt.Errorf("inspect image %s: %w", baseImage, err)
+	}
+
+	binDir := "/usr/local/bin"
+	if base.Config != nil {
+		for _, env := range base.Config.Env {
+			if p := strings.TrimPrefix(env, "PATH="); p != env && p != "" {
+				binDir = strings.Split(p, ":")[0]
+				break
+			}
+		}
+	}
+
+	contextDir, err := os.MkdirTemp("", "ibctest-build-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(contextDir)
+
+	if _, err := CopyFile(binaryPath, filepath.Join(contextDir, "bin")); err != nil {
+		return "", fmt.Errorf("copy binary %s: %w", binaryPath, err)
+	}
+	if err := os.Chmod(filepath.Join(contextDir, "bin"), 0755); err != nil {
+		return "", err
+	}
+	dockerfile := fmt.Sprintf("FROM %s\nCOPY bin %s\n", baseImage, path.Join(binDir, bin))
+	if err := os.WriteFile(filepath.Join(contextDir, "Dockerfile"), []byte(dockerfile), 0644); err != nil { //nolint
+		return "", err
+	}
+
+	return BuildImage(ctx, cli, repository, contextDir, "Dockerfile")
+}
+
+// writeTar writes the directory tree at dir to w as a tar archive.
+// Modification times and ownership are omitted, so that the archive only changes when the contents of dir do.
+func writeTar(w io.Writer, dir string) error {
+	tw := tar.NewWriter(w)
+	err := filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+
+		var link string
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(p); err != nil {
+				return err
+			}
+		}
+		hdr, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(rel)
+		if info.IsDir() {
+			hdr.Name += "/"
+		}
+		hdr.ModTime = time.Unix(0, 0)
+		hdr.AccessTime, hdr.ChangeTime = time.Time{}, time.Time{}
+		hdr.Uid, hdr.Gid, hdr.Uname, hdr.Gname = 0, 0, "", ""
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return tw.Close()
+}
+
+// readBuildOutput consumes the JSON messages streamed from an image build, and returns the error reported by the build, if any.
+func readBuildOutput(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var msg struct {
+			Error string `json:"error"`
+		}
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("decode build output: %w", err)
+		}
+		if msg.Error != "" {
+			return errors.New(msg.Error)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package dockerutil
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteTar(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM busybox\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "file"), []byte("contents"), 0644))
+
+	var before bytes.Buffer
+	require.NoError(t, writeTar(&before, dir))
+
+	// Touching files must not change the archive.
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "sub", "file"), later, later))
+	var touched bytes.Buffer
+	require.NoError(t, writeTar(&touched, dir))
+	require.Equal(t, before.Bytes(), touched.Bytes())
+
+	// Changing contents must.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "file"), []byte("changed"), 0644))
+	var changed bytes.Buffer
+	require.NoError(t, writeTar(&changed, dir))
+	require.NotEqual(t, before.Bytes(), changed.Bytes())
+}
+
+func TestReadBuildOutput(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, readBuildOutput(strings.NewReader(`{"stream":"Step 1/1 : FROM busybox"}
+{"stream":"Successfully built 0123456789ab"}
+`)))
+
+	require.EqualError(t, readBuildOutput(strings.NewReader(`{"stream":"Step 1/2 : FROM busybox"}
+{"errorDetail":{"message":"COPY failed"},"error":"COPY failed"}
+`)), "COPY failed")
+}
+
+func TestBuildImage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	cli, err := client.NewClientWithOpts(client.FromEnv)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM busybox\nCOPY greeting /greeting\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "greeting"), []byte(t.Name()+time.Now().String()), 0644))
+
+	ctx := context.Background()
+	tag, err := BuildImage(ctx, cli, "ibctest-test", dir, "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = cli.ImageRemove(ctx, "ibctest-test:"+tag, types.ImageRemoveOptions{Force: true})
+	})
+
+	// Building the same context again must reuse the image.
+	again, err := BuildImage(ctx, cli, "ibctest-test", dir, "Dockerfile")
+	require.NoError(t, err)
+	require.Equal(t, tag, again)
+}
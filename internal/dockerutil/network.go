@@ -0,0 +1,37 @@
+package dockerutil
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/client"
+)
+
+// CreateNetwork creates a new network, labeled to be cleaned up by DockerSetup along with the other resources of testName,
+// and returns the network's ID.
+func CreateNetwork(ctx context.Context, cli *client.Client, testName string) (string, error) {
+	name := fmt.Sprintf("ibctest-%s", RandLowerCaseLetterString(8))
+	network, err := cli.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+
+		Labels: map[string]string{CleanupLabel: testName},
+	})
+	if err != nil {
+		return "", err
+	}
+	return network.ID, nil
+}
+
+// DisconnectContainer disconnects the container with the given ID from the network with the given ID,
+// so that it can no longer reach, or be reached by, the other containers on that network.
+func DisconnectContainer(ctx context.Context, cli *client.Client, networkID, containerID string) error {
+	return cli.NetworkDisconnect(ctx, networkID, containerID, true)
+}
+
+// ConnectContainer connects the container with the given ID to the network with the given ID.
+// A container that was disconnected and connected again may be assigned a different IP address on the network.
+func ConnectContainer(ctx context.Context, cli *client.Client, networkID, containerID string) error {
+	return cli.NetworkConnect(ctx, networkID, containerID, &network.EndpointSettings{})
+}
@@ -2,7 +2,6 @@ package dockerutil
 
 import (
 	"context"
-	"fmt"
 	"testing"
 	"time"
 
@@ -39,17 +38,12 @@ func DockerSetup(t *testing.T) (*client.Client, string) {
 	// e.g. if the test was interrupted.
 	dockerCleanup(t, cli)()
 
-	name := fmt.Sprintf("ibctest-%s", RandLowerCaseLetterString(8))
-	network, err := cli.NetworkCreate(context.TODO(), name, types.NetworkCreate{
-		CheckDuplicate: true,
-
-		Labels: map[string]string{CleanupLabel: t.Name()},
-	})
+	networkID, err := CreateNetwork(context.TODO(), cli, t.Name())
 	if err != nil {
 		t.Fatalf("failed to create docker network: %v", err)
 	}
 
-	return cli, network.ID
+	return cli, networkID
 }
 
 // dockerCleanup will clean up Docker containers, networks, and the other various config files generated in testing
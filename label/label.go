@@ -30,13 +30,15 @@ func (l Test) IsKnown() bool {
 type Relayer string
 
 const (
-	Rly    Relayer = "rly"
-	Hermes Relayer = "hermes"
+	Rly       Relayer = "rly"
+	Hermes    Relayer = "hermes"
+	InProcess Relayer = "inprocess"
 )
 
 var knownRelayerLabels = map[Relayer]struct{}{
-	Rly:    {},
-	Hermes: {},
+	Rly:       {},
+	Hermes:    {},
+	InProcess: {},
 }
 
 func (l Relayer) IsKnown() bool {
@@ -18,6 +18,25 @@ const (
 	// Whether the relayer supports a one-off flush packets or flush acknowledgements command.
 	FlushPackets
 	FlushAcknowledgements
+
+	// Whether the relayer completes handshakes for, and relays packets on, ordered channels.
+	OrderedChannels
+
+	// Whether the relayer relays channel close handshakes.
+	ChannelClose
+
+	// Whether the relayer detects conflicting headers and submits client misbehaviour.
+	Misbehaviour
+
+	// Whether the relayer creates connections with the delay period of ibc.PathOptions.ConnectionDelay,
+	// and waits for the delay to pass before relaying packets over them.
+	ConnectionDelay
+
+	// Whether a single running relayer can relay on more than one path at a time.
+	MultiplePaths
+
+	// Whether the relayer can be restricted to a subset of channels on a path.
+	PacketFilter
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -31,5 +50,12 @@ func FullCapabilities() map[Capability]bool {
 
 		FlushPackets:          true,
 		FlushAcknowledgements: true,
+
+		OrderedChannels: true,
+		ChannelClose:    true,
+		Misbehaviour:    true,
+		ConnectionDelay: true,
+		MultiplePaths:   true,
+		PacketFilter:    true,
 	}
 }
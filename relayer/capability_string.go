@@ -12,11 +12,17 @@ func _() {
 	_ = x[HeightTimeout-1]
 	_ = x[FlushPackets-2]
 	_ = x[FlushAcknowledgements-3]
+	_ = x[OrderedChannels-4]
+	_ = x[ChannelClose-5]
+	_ = x[Misbehaviour-6]
+	_ = x[ConnectionDelay-7]
+	_ = x[MultiplePaths-8]
+	_ = x[PacketFilter-9]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgements"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushPacketsFlushAcknowledgementsOrderedChannelsChannelCloseMisbehaviourConnectionDelayMultiplePathsPacketFilter"
 
-var _Capability_index = [...]uint8{0, 16, 29, 41, 62}
+var _Capability_index = [...]uint8{0, 16, 29, 41, 62, 77, 89, 101, 116, 129, 141}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types"
@@ -17,6 +18,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/docker/go-connections/nat"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
 	"go.uber.org/zap"
@@ -39,16 +41,42 @@ type DockerRelayer struct {
 	customImage *ibc.DockerImage
 	pullImage   bool
 
-	// The ID of the container created by StartRelayer.
+	// mu guards containerID, which MetricsURL reads while the metrics collector runs concurrently with the test.
+	// containerID is only written with mu held, so the test's own goroutine may read it without mu.
+	mu sync.Mutex
+
+	// The ID of the container created by StartRelayer, or empty once StopRelayer removes it.
 	containerID string
 
+	// The name of the container created by StartRelayer.
+	containerName string
+
+	// Whether the container created by StartRelayer is running.
+	running bool
+
+	// The paths passed to the most recent StartRelayer, which may be none.
+	startedPaths []string
+
+	// Closed when the log stream of the container created by StartRelayer ends.
+	// Nil if the container's logs are not being streamed.
+	logsDone chan struct{}
+
 	didInit bool
 
 	// wallets contains a mapping of chainID to relayer wallet
 	wallets map[string]ibc.RelayerWallet
+
+	// pathSrcChains contains a mapping of path name to the path's source chain ID
+	pathSrcChains map[string]string
 }
 
-var _ ibc.Relayer = (*DockerRelayer)(nil)
+var (
+	_ ibc.FaultInjectableRelayer        = (*DockerRelayer)(nil)
+	_ ibc.NetworkFaultInjectableRelayer = (*DockerRelayer)(nil)
+	_ ibc.PathSharingRelayer            = (*DockerRelayer)(nil)
+	_ ibc.MetricsRelayer                = (*DockerRelayer)(nil)
+	_ ibc.UpgradeableRelayer            = (*DockerRelayer)(nil)
+)
 
 // NewDockerRelayer returns a new DockerRelayer.
 func NewDockerRelayer(log *zap.Logger, testName, home string, cli *client.Client, networkID string, c RelayerCommander, options ...RelayerOption) *DockerRelayer {
@@ -67,6 +95,8 @@ func NewDockerRelayer(log *zap.Logger, testName, home string, cli *client.Client
 		testName: testName,
 
 		wallets: map[string]ibc.RelayerWallet{},
+
+		pathSrcChains: map[string]string{},
 	}
 
 	for _, opt := range options {
@@ -79,7 +109,7 @@ func NewDockerRelayer(log *zap.Logger, testName, home string, cli *client.Client
 	}
 
 	containerImage := relayer.containerImage()
-	if err := relayer.pullContainerImageIfNecessary(containerImage); err != nil {
+	if err := relayer.pullContainerImageIfNecessary(context.TODO(), containerImage); err != nil {
 		log.Error("Error pulling container image", zap.String("ref", containerImage.Ref()), zap.Error(err))
 	}
 
@@ -132,7 +162,10 @@ func (r *DockerRelayer) AddChainConfiguration(ctx context.Context, rep ibc.Relay
 }
 
 func (r *DockerRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName string) (ibc.RelayerWallet, error) {
-	cmd := r.c.AddKey(chainID, keyName, r.NodeHome())
+	cmd, err := r.c.AddKey(chainID, keyName, r.NodeHome())
+	if err != nil {
+		return ibc.RelayerWallet{}, err
+	}
 
 	// Adding a key should be near-instantaneous, so add a 1-minute timeout
 	// to detect if Docker has hung.
@@ -162,6 +195,36 @@ func (r *DockerRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecRe
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
 }
 
+func (r *DockerRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.CloseChannel(pathName, channelID, portID, r.NodeHome())
+	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
+}
+
+// channelPortID returns the port of the channel with the given ID on the path's source chain.
+// The channel's port is needed to identify it, but it is not part of the path configuration.
+func (r *DockerRelayer) channelPortID(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (string, error) {
+	srcChainID, ok := r.pathSrcChains[pathName]
+	if !ok {
+		return "", fmt.Errorf("path %s was never generated", pathName)
+	}
+
+	channels, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range channels {
+		if c.ChannelID == channelID {
+			return c.PortID, nil
+		}
+	}
+	return "", fmt.Errorf("channel %s not found on chain %s", channelID, srcChainID)
+}
+
 func (r *DockerRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
 	cmd := r.c.CreateClients(pathName, r.NodeHome())
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
@@ -173,18 +236,76 @@ func (r *DockerRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerEx
 }
 
 func (r *DockerRelayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
-	cmd := r.c.FlushAcknowledgements(pathName, channelID, r.NodeHome())
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.FlushAcknowledgements(pathName, channelID, portID, r.NodeHome())
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
 }
 
 func (r *DockerRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
-	cmd := r.c.FlushPackets(pathName, channelID, r.NodeHome())
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.FlushPackets(pathName, channelID, portID, r.NodeHome())
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
 }
 
-func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string) error {
+func (r *DockerRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.PathOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid path options: %w", err)
+	}
+
 	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.NodeHome())
-	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
+
+	// Some relayers cannot set every option when the path is created,
+	// so they apply the options to the new path.
+	// Resolve that command first, so that unsupported options are rejected before the path exists.
+	updateCmd, err := r.c.UpdatePath(pathName, opts, r.NodeHome())
+	if err != nil {
+		return fmt.Errorf("failed to apply path options: %w", err)
+	}
+
+	if err := dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd)); err != nil {
+		return err
+	}
+
+	r.pathSrcChains[pathName] = srcChainID
+
+	if len(updateCmd) > 0 {
+		if err := dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, updateCmd)); err != nil {
+			return fmt.Errorf("failed to apply path options: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *DockerRelayer) GenerateSharedPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) error {
+	pathFile := pathName + ".path"
+	pathLocalFilePath := filepath.Join(r.Dir(), pathFile)
+	pathContainerFilePath := fmt.Sprintf("%s/%s", r.NodeHome(), pathFile)
+
+	content, err := r.c.SharedPathContent(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to generate path content: %w", err)
+	}
+	if len(content) > 0 {
+		if err := os.WriteFile(pathLocalFilePath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write path to host disk: %w", err)
+		}
+	}
+
+	cmd := r.c.GenerateSharedPath(pathName, src, dst, pathContainerFilePath, r.NodeHome())
+	if err := dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd)); err != nil {
+		return err
+	}
+
+	r.pathSrcChains[pathName] = src.ChainID
+	return nil
 }
 
 func (r *DockerRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
@@ -212,6 +333,21 @@ func (r *DockerRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecR
 	return r.c.ParseGetConnectionsOutput(stdout, stderr)
 }
 
+func (r *DockerRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ClientOutput, error) {
+	cmd := r.c.GetClients(chainID, r.NodeHome())
+
+	// Getting clients should be very quick, but go up to a 3-minute timeout just in case.
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	exitCode, stdout, stderr, err := r.NodeJob(ctx, rep, cmd)
+	if err != nil {
+		return nil, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	return r.c.ParseGetClientsOutput(stdout, stderr)
+}
+
 func (r *DockerRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
 	cmd := r.c.LinkPath(pathName, r.NodeHome(), opts)
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
@@ -242,14 +378,210 @@ func (r *DockerRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecRe
 	return dockerutil.HandleNodeJobError(r.NodeJob(ctx, rep, cmd))
 }
 
-func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
-	return r.createNodeContainer(ctx, pathName)
+func (r *DockerRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	containerName, err := r.createNodeContainer(ctx, pathNames...)
+	if err != nil {
+		return err
+	}
+	r.running = true
+	r.startedPaths = pathNames
+	r.containerName = containerName
+
+	if logRep, ok := rep.(ibc.RelayerLogReporter); ok {
+		if err := r.streamLogs(logRep, containerName, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// streamLogs follows the output of the container created by StartRelayer,
+// tracking each line through rep until the container stops.
+// If since is not empty, only output produced after that timestamp is tracked.
+func (r *DockerRelayer) streamLogs(rep ibc.RelayerLogReporter, containerName, since string) error {
+	// The log stream outlives the context passed to StartRelayer,
+	// and ends when the container is stopped in StopRelayer.
+	rc, err := r.client.ContainerLogs(context.Background(), r.containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+		Since:      since,
+	})
+	if err != nil {
+		return fmt.Errorf("following ContainerLogs: %w", err)
+	}
+
+	done := make(chan struct{})
+	r.logsDone = done
+
+	go func() {
+		defer close(done)
+		defer func() { _ = rc.Close() }()
+
+		stdout := newLogLineWriter(rep, containerName, "stdout")
+		stderr := newLogLineWriter(rep, containerName, "stderr")
+
+		// Logs are multiplexed into one stream; see docs for ContainerLogs.
+		if _, err := stdcopy.StdCopy(stdout, stderr, rc); err != nil {
+			r.log.Info("Failed to stream relayer logs", zap.String("container", containerName), zap.Error(err))
+		}
+		stdout.Flush()
+		stderr.Flush()
+	}()
+
+	return nil
+}
+
+// setContainerID records id as the ID of the relayer container.
+func (r *DockerRelayer) setContainerID(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.containerID = id
+}
+
+func (r *DockerRelayer) MetricsURL(ctx context.Context) (string, error) {
+	port, path := r.c.MetricsEndpoint()
+	r.mu.Lock()
+	containerID := r.containerID
+	r.mu.Unlock()
+	if port == "" || containerID == "" {
+		return "", nil
+	}
+
+	c, err := r.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return "", fmt.Errorf("inspecting container: %w", err)
+	}
+	if !c.State.Running || c.State.Paused {
+		return "", nil
+	}
+
+	// The host port is assigned each time the container starts.
+	hostPort := dockerutil.GetHostPort(c, port)
+	if hostPort == "" {
+		return "", nil
+	}
+	return "http://" + hostPort + path, nil
+}
+
+func (r *DockerRelayer) PauseRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if err := r.containerAction(ctx, rep, r.client.ContainerPause, "pause"); err != nil {
+		return fmt.Errorf("PauseRelayer: %w", err)
+	}
+	return nil
+}
+
+func (r *DockerRelayer) ResumeRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if err := r.containerAction(ctx, rep, r.client.ContainerUnpause, "unpause"); err != nil {
+		return fmt.Errorf("ResumeRelayer: %w", err)
+	}
+	return nil
+}
+
+// containerAction applies action to the container created by StartRelayer,
+// tracking it through rep as the equivalent docker command: docker, then args, then the container ID.
+func (r *DockerRelayer) containerAction(ctx context.Context, rep ibc.RelayerExecReporter, action func(ctx context.Context, containerID string) error, args ...string) error {
+	if r.containerID == "" {
+		return errors.New("relayer container is not running")
+	}
+
+	startedAt := time.Now()
+	err := action(ctx, r.containerID)
+
+	exitCode := 0
+	if err != nil {
+		exitCode = 1
+	}
+	rep.TrackRelayerExec(
+		r.containerName,
+		append(append([]string{"docker"}, args...), r.containerID),
+		"", "",
+		exitCode,
+		startedAt,
+		time.Now(),
+		err,
+	)
+	return err
+}
+
+func (r *DockerRelayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	if err := r.containerAction(ctx, rep, r.restartContainer, "restart"); err != nil {
+		return fmt.Errorf("RestartRelayer: %w", err)
+	}
+
+	if logRep, ok := rep.(ibc.RelayerLogReporter); ok {
+		c, err := r.client.ContainerInspect(ctx, r.containerID)
+		if err != nil {
+			return fmt.Errorf("RestartRelayer: inspecting container: %w", err)
+		}
+		// The new log stream begins where the previous one ended.
+		return r.streamLogs(logRep, strings.TrimPrefix(c.Name, "/"), c.State.StartedAt)
+	}
+	return nil
+}
+
+// restartContainer kills the relayer container, without letting the relayer shut down gracefully,
+// and starts it again once its log stream has ended.
+func (r *DockerRelayer) restartContainer(ctx context.Context, containerID string) error {
+	if err := r.client.ContainerKill(ctx, containerID, "SIGKILL"); err != nil {
+		return fmt.Errorf("killing container: %w", err)
+	}
+	if err := r.waitForLogs(ctx); err != nil {
+		return err
+	}
+
+	if err := dockerutil.StartContainer(ctx, r.client, containerID); err != nil {
+		return fmt.Errorf("starting container: %w", err)
+	}
+	return nil
+}
+
+func (r *DockerRelayer) DisconnectRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	disconnect := func(ctx context.Context, containerID string) error {
+		return dockerutil.DisconnectContainer(ctx, r.client, r.networkID, containerID)
+	}
+	if err := r.containerAction(ctx, rep, disconnect, "network", "disconnect", r.networkID); err != nil {
+		return fmt.Errorf("DisconnectRelayer: %w", err)
+	}
+	return nil
+}
+
+func (r *DockerRelayer) ReconnectRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	connect := func(ctx context.Context, containerID string) error {
+		return dockerutil.ConnectContainer(ctx, r.client, r.networkID, containerID)
+	}
+	if err := r.containerAction(ctx, rep, connect, "network", "connect", r.networkID); err != nil {
+		return fmt.Errorf("ReconnectRelayer: %w", err)
+	}
+	return nil
+}
+
+// waitForLogs waits for the log stream of a stopped relayer container to end,
+// so that its remaining output has been tracked.
+func (r *DockerRelayer) waitForLogs(ctx context.Context) error {
+	if r.logsDone == nil {
+		return nil
+	}
+
+	select {
+	case <-r.logsDone:
+		r.logsDone = nil
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for log stream: %w", ctx.Err())
+	}
 }
 
 func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
 	if err := r.stopContainer(ctx); err != nil {
 		return err
 	}
+	r.running = false
+
+	if err := r.waitForLogs(ctx); err != nil {
+		return fmt.Errorf("StopRelayer: %w", err)
+	}
 
 	stdoutBuf := new(bytes.Buffer)
 	stderrBuf := new(bytes.Buffer)
@@ -306,10 +638,40 @@ func (r *DockerRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecRepo
 		zap.String("container", c.Name),
 	)
 
-	return r.client.ContainerRemove(ctx, r.containerID, types.ContainerRemoveOptions{
+	if err := r.client.ContainerRemove(ctx, r.containerID, types.ContainerRemoveOptions{
 		RemoveVolumes: true,
 		// TODO: should this set Force=true?
-	})
+	}); err != nil {
+		return err
+	}
+
+	r.setContainerID("")
+	return nil
+}
+
+func (r *DockerRelayer) UpgradeRelayer(ctx context.Context, rep ibc.RelayerExecReporter, newImage ibc.DockerImage) error {
+	// Pull before stopping, so that a failed pull leaves the previous release running.
+	if err := r.pullContainerImageIfNecessary(ctx, newImage); err != nil {
+		return fmt.Errorf("UpgradeRelayer: pulling image %s: %w", newImage.Ref(), err)
+	}
+
+	running, pathNames := r.running, r.startedPaths
+	if running {
+		if err := r.StopRelayer(ctx, rep); err != nil {
+			return fmt.Errorf("UpgradeRelayer: stopping relayer: %w", err)
+		}
+	}
+
+	// The relayer's home directory is bind mounted from the host,
+	// so the configuration and keys written by the previous image remain available to the new one.
+	r.customImage = &newImage
+
+	if running {
+		if err := r.StartRelayer(ctx, rep, pathNames...); err != nil {
+			return fmt.Errorf("UpgradeRelayer: starting relayer: %w", err)
+		}
+	}
+	return nil
 }
 
 func (r *DockerRelayer) containerImage() ibc.DockerImage {
@@ -322,12 +684,12 @@ func (r *DockerRelayer) containerImage() ibc.DockerImage {
 	}
 }
 
-func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerImage) error {
+func (r *DockerRelayer) pullContainerImageIfNecessary(ctx context.Context, containerImage ibc.DockerImage) error {
 	if !r.pullImage {
 		return nil
 	}
 
-	rc, err := r.client.ImagePull(context.TODO(), containerImage.Ref(), types.ImagePullOptions{})
+	rc, err := r.client.ImagePull(ctx, containerImage.Ref(), types.ImagePullOptions{})
 	if err != nil {
 		return err
 	}
@@ -337,10 +699,21 @@ func (r *DockerRelayer) pullContainerImageIfNecessary(containerImage ibc.DockerI
 	return nil
 }
 
-func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string) error {
+// createNodeContainer creates and starts the long-running relayer container for the given paths,
+// returning the name of the container.
+func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathNames ...string) (string, error) {
 	containerImage := r.containerImage()
-	containerName := fmt.Sprintf("%s-%s", r.c.Name(), pathName)
-	cmd := r.c.StartRelayer(pathName, r.NodeHome())
+	joinedPaths := strings.Join(pathNames, ".")
+	// Multiple relayers may run on the same paths, even within a single test.
+	containerName := fmt.Sprintf("%s-%s-%s", r.Name(), joinedPaths, dockerutil.RandLowerCaseLetterString(3))
+	cmd := r.c.StartRelayer(r.NodeHome(), pathNames...)
+
+	// Publish the metrics port, if any, so that the metrics can be scraped from the host.
+	var exposedPorts nat.PortSet
+	if metricsPort, _ := r.c.MetricsEndpoint(); metricsPort != "" {
+		exposedPorts = nat.PortSet{nat.Port(metricsPort): {}}
+	}
+
 	r.log.Info(
 		"Running command",
 		zap.String("command", strings.Join(cmd, " ")),
@@ -354,14 +727,17 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string
 			Entrypoint: []string{},
 			Cmd:        cmd,
 
-			Hostname: r.HostName(pathName),
+			Hostname: r.HostName(joinedPaths),
 			User:     dockerutil.GetDockerUserString(),
 
 			Labels: map[string]string{dockerutil.CleanupLabel: r.testName},
+
+			ExposedPorts: exposedPorts,
 		},
 		&container.HostConfig{
-			Binds:      r.Bind(),
-			AutoRemove: false,
+			Binds:           r.Bind(),
+			PublishAllPorts: true,
+			AutoRemove:      false,
 		},
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
@@ -372,11 +748,11 @@ func (r *DockerRelayer) createNodeContainer(ctx context.Context, pathName string
 		containerName,
 	)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	r.containerID = cc.ID
-	return dockerutil.StartContainer(ctx, r.client, r.containerID)
+	r.setContainerID(cc.ID)
+	return containerName, dockerutil.StartContainer(ctx, r.client, cc.ID)
 }
 
 func (r *DockerRelayer) NodeJob(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string) (
@@ -544,6 +920,19 @@ type RelayerCommander interface {
 	// to produce the connection output values.
 	ParseGetConnectionsOutput(stdout, stderr string) (ibc.ConnectionOutputs, error)
 
+	// ParseGetClientsOutput processes the output of GetClients
+	// to produce the client output values.
+	ParseGetClientsOutput(stdout, stderr string) ([]ibc.ClientOutput, error)
+
+	// SharedPathContent generates the content of the file that will be passed to GenerateSharedPath.
+	// If the returned content is empty, no file is written.
+	SharedPathContent(src, dst ibc.PathEnd) ([]byte, error)
+
+	// MetricsEndpoint returns the container port, e.g. "5183/tcp", and the HTTP path
+	// on which the relayer started through StartRelayer serves Prometheus metrics.
+	// If the relayer was not configured to serve metrics, the returned port is empty.
+	MetricsEndpoint() (port, path string)
+
 	// Init is the command to run on the first call to AddChainConfiguration.
 	// If the returned command is nil or empty, nothing will be executed.
 	Init(homeDir string) []string
@@ -551,17 +940,26 @@ type RelayerCommander interface {
 	// The remaining methods produce the command to run inside the container.
 
 	AddChainConfiguration(containerFilePath, homeDir string) []string
-	AddKey(chainID, keyName, homeDir string) []string
+	// AddKey returns an error if the key cannot be prepared, such as when generating its mnemonic fails.
+	AddKey(chainID, keyName, homeDir string) ([]string, error)
+	CloseChannel(pathName, channelID, portID, homeDir string) []string
 	CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string
 	CreateClients(pathName, homeDir string) []string
 	CreateConnections(pathName, homeDir string) []string
-	FlushAcknowledgements(pathName, channelID, homeDir string) []string
-	FlushPackets(pathName, channelID, homeDir string) []string
+	FlushAcknowledgements(pathName, channelID, portID, homeDir string) []string
+	FlushPackets(pathName, channelID, portID, homeDir string) []string
 	GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string
 	GetChannels(chainID, homeDir string) []string
+	GetClients(chainID, homeDir string) []string
 	GetConnections(chainID, homeDir string) []string
+	GenerateSharedPath(pathName string, src, dst ibc.PathEnd, containerFilePath, homeDir string) []string
 	LinkPath(pathName, homeDir string, opts ibc.CreateChannelOptions) []string
 	RestoreKey(chainID, keyName, mnemonic, homeDir string) []string
-	StartRelayer(pathName, homeDir string) []string
+	StartRelayer(homeDir string, pathNames ...string) []string
 	UpdateClients(pathName, homeDir string) []string
+
+	// UpdatePath is the command to run after GeneratePath, to apply opts to the new path.
+	// If the returned command is nil or empty, nothing will be executed.
+	// UpdatePath returns an error if the relayer does not support opts.
+	UpdatePath(pathName string, opts ibc.PathOptions, homeDir string) ([]string, error)
 }
@@ -0,0 +1,141 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newUnreachableDockerRelayer returns a DockerRelayer running oldImage,
+// whose Docker client fails every request.
+func newUnreachableDockerRelayer(t *testing.T, oldImage ibc.DockerImage) *DockerRelayer {
+	t.Helper()
+
+	cli, err := client.NewClientWithOpts(client.WithHost("tcp://127.0.0.1:1"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = cli.Close() })
+
+	return NewDockerRelayer(
+		zap.NewNop(), t.Name(), t.TempDir(), cli, "", shCommander{},
+		CustomDockerImage(oldImage.Repository, oldImage.Version), ImagePull(false),
+	)
+}
+
+func TestDockerRelayer_UpgradeRelayer(t *testing.T) {
+	oldImage := ibc.DockerImage{Repository: "relayer", Version: "v1"}
+	newImage := ibc.DockerImage{Repository: "relayer", Version: "v2"}
+
+	ctx := context.Background()
+
+	t.Run("stopped relayer", func(t *testing.T) {
+		r := newUnreachableDockerRelayer(t, oldImage)
+
+		// No Docker request is needed to upgrade a stopped relayer without pulling.
+		require.NoError(t, r.UpgradeRelayer(ctx, new(mockExecReporter), newImage))
+		require.Equal(t, newImage, r.containerImage())
+		require.False(t, r.running)
+	})
+
+	t.Run("failed pull keeps running relayer", func(t *testing.T) {
+		r := newUnreachableDockerRelayer(t, oldImage)
+		r.pullImage = true
+		r.running = true
+
+		err := r.UpgradeRelayer(ctx, new(mockExecReporter), newImage)
+		require.ErrorContains(t, err, "pulling image")
+		require.Equal(t, oldImage, r.containerImage())
+		require.True(t, r.running)
+	})
+
+	t.Run("relayer started without paths", func(t *testing.T) {
+		r := newUnreachableDockerRelayer(t, oldImage)
+		r.running = true
+
+		// StartRelayer records no paths when called without any,
+		// but the relayer must still be stopped before switching images.
+		err := r.UpgradeRelayer(ctx, new(mockExecReporter), newImage)
+		require.ErrorContains(t, err, "stopping relayer")
+		require.Equal(t, oldImage, r.containerImage())
+	})
+}
+
+func TestDockerRelayer_PauseResume(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("not running", func(t *testing.T) {
+		r := newUnreachableDockerRelayer(t, ibc.DockerImage{Repository: "relayer", Version: "v1"})
+		rep := new(mockExecReporter)
+
+		require.ErrorContains(t, r.PauseRelayer(ctx, rep), "not running")
+		require.ErrorContains(t, r.ResumeRelayer(ctx, rep), "not running")
+		require.Empty(t, rep.execs)
+	})
+
+	t.Run("tracked", func(t *testing.T) {
+		r := newUnreachableDockerRelayer(t, ibc.DockerImage{Repository: "relayer", Version: "v1"})
+		r.setContainerID("abc")
+		r.containerName = "relayer-p"
+		rep := new(mockExecReporter)
+
+		// The Docker client cannot connect, so both actions fail, but they are still tracked.
+		require.Error(t, r.PauseRelayer(ctx, rep))
+		require.Error(t, r.ResumeRelayer(ctx, rep))
+
+		require.Len(t, rep.execs, 2)
+		for i, action := range []string{"pause", "unpause"} {
+			exec := rep.execs[i]
+			require.Equal(t, "relayer-p", exec.ContainerName)
+			require.Equal(t, []string{"docker", action, "abc"}, exec.Command)
+			require.Equal(t, 1, exec.ExitCode)
+			require.Error(t, exec.Err)
+		}
+	})
+}
+
+func TestDockerRelayer_RestartRelayer(t *testing.T) {
+	ctx := context.Background()
+
+	r := newUnreachableDockerRelayer(t, ibc.DockerImage{Repository: "relayer", Version: "v1"})
+	rep := new(mockExecReporter)
+
+	require.ErrorContains(t, r.RestartRelayer(ctx, rep), "not running")
+	require.Empty(t, rep.execs)
+
+	r.setContainerID("abc")
+	r.containerName = "relayer-p"
+
+	require.ErrorContains(t, r.RestartRelayer(ctx, rep), "killing container")
+	require.Len(t, rep.execs, 1)
+	require.Equal(t, []string{"docker", "restart", "abc"}, rep.execs[0].Command)
+	require.Equal(t, 1, rep.execs[0].ExitCode)
+}
+
+func TestDockerRelayer_DisconnectReconnect(t *testing.T) {
+	ctx := context.Background()
+
+	r := newUnreachableDockerRelayer(t, ibc.DockerImage{Repository: "relayer", Version: "v1"})
+	rep := new(mockExecReporter)
+
+	require.ErrorContains(t, r.DisconnectRelayer(ctx, rep), "not running")
+	require.ErrorContains(t, r.ReconnectRelayer(ctx, rep), "not running")
+	require.Empty(t, rep.execs)
+
+	r.setContainerID("abc")
+	r.containerName = "relayer-p"
+
+	// The Docker client cannot connect, so both network faults fail, but they are still tracked.
+	require.Error(t, r.DisconnectRelayer(ctx, rep))
+	require.Error(t, r.ReconnectRelayer(ctx, rep))
+
+	require.Len(t, rep.execs, 2)
+	for i, action := range []string{"disconnect", "connect"} {
+		exec := rep.execs[i]
+		require.Equal(t, "relayer-p", exec.ContainerName)
+		require.Equal(t, []string{"docker", "network", action, r.networkID, "abc"}, exec.Command)
+		require.Equal(t, 1, exec.ExitCode)
+	}
+}
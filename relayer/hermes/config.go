@@ -0,0 +1,112 @@
+package hermes
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// globalConfig is the portion of the hermes config file that is not specific to any chain.
+// It is written during Init, and each chain's section is appended to it afterwards.
+// The REST and telemetry ports are filled in with fmt.Sprintf.
+const globalConfig = `[global]
+log_level = 'info'
+
+[mode.clients]
+enabled = true
+refresh = true
+misbehaviour = true
+
+[mode.connections]
+enabled = true
+
+[mode.channels]
+enabled = true
+
+[mode.packets]
+enabled = true
+clear_interval = 100
+clear_on_start = true
+tx_confirmation = true
+
+[rest]
+enabled = false
+host = '0.0.0.0'
+port = %[1]s
+
+[telemetry]
+enabled = false
+host = '0.0.0.0'
+port = %[2]s
+`
+
+// Ports of the REST and telemetry servers configured in globalConfig, when running in a container.
+// A relayer running on the host picks free ports instead.
+const (
+	defaultRestPort      = "3000"
+	defaultTelemetryPort = "3001"
+)
+
+// gasPriceRe splits a gas price such as "0.01uatom" into its amount and denom.
+var gasPriceRe = regexp.MustCompile(`^([0-9]*\.?[0-9]+)([a-zA-Z][a-zA-Z0-9/:._-]*)$`)
+
+// ChainConfigToHermesChainConfig returns the [[chains]] section of the hermes config file
+// for the chain described by chainConfig.
+func ChainConfigToHermesChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
+	m := gasPriceRe.FindStringSubmatch(strings.TrimSpace(chainConfig.GasPrices))
+	if m == nil {
+		return nil, fmt.Errorf("invalid gas prices %q for chain %s", chainConfig.GasPrices, chainConfig.ChainID)
+	}
+	gasPrice, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas price amount %q for chain %s: %w", m[1], chainConfig.ChainID, err)
+	}
+
+	gasMultiplier := chainConfig.GasAdjustment
+	if gasMultiplier < 1 {
+		// Hermes rejects a multiplier below 1.
+		gasMultiplier = 1
+	}
+
+	rpcHost := strings.TrimPrefix(strings.TrimPrefix(rpcAddr, "http://"), "https://")
+	if !strings.Contains(grpcAddr, "://") {
+		grpcAddr = "http://" + grpcAddr
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "\n[[chains]]\n")
+	fmt.Fprintf(&buf, "id = %q\n", chainConfig.ChainID)
+	fmt.Fprintf(&buf, "rpc_addr = %q\n", rpcAddr)
+	fmt.Fprintf(&buf, "grpc_addr = %q\n", grpcAddr)
+	fmt.Fprintf(&buf, "websocket_addr = %q\n", "ws://"+rpcHost+"/websocket")
+	fmt.Fprintf(&buf, "rpc_timeout = '10s'\n")
+	fmt.Fprintf(&buf, "account_prefix = %q\n", chainConfig.Bech32Prefix)
+	fmt.Fprintf(&buf, "key_name = %q\n", keyName)
+	fmt.Fprintf(&buf, "store_prefix = 'ibc'\n")
+	fmt.Fprintf(&buf, "default_gas = 100000\n")
+	fmt.Fprintf(&buf, "max_gas = 3000000\n")
+	fmt.Fprintf(&buf, "gas_price = { price = %s, denom = %q }\n", tomlFloat(gasPrice), m[2])
+	fmt.Fprintf(&buf, "gas_multiplier = %s\n", tomlFloat(gasMultiplier))
+	fmt.Fprintf(&buf, "max_msg_num = 30\n")
+	fmt.Fprintf(&buf, "max_tx_size = 2097152\n")
+	fmt.Fprintf(&buf, "clock_drift = '5s'\n")
+	fmt.Fprintf(&buf, "max_block_time = '30s'\n")
+	fmt.Fprintf(&buf, "trusting_period = %q\n", chainConfig.TrustingPeriod)
+	fmt.Fprintf(&buf, "trust_threshold = { numerator = '1', denominator = '3' }\n")
+	fmt.Fprintf(&buf, "address_type = { derivation = 'cosmos' }\n")
+
+	return buf.Bytes(), nil
+}
+
+// tomlFloat formats f so that TOML always parses it as a float rather than an integer.
+func tomlFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
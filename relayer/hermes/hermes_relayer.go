@@ -0,0 +1,537 @@
+// Package hermes provides an interface to the Hermes relayer running in a Docker container or on the host.
+package hermes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/docker/docker/client"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"go.uber.org/zap"
+)
+
+// HermesRelayer is the ibc.Relayer implementation for github.com/informalsystems/ibc-rs.
+type HermesRelayer struct {
+	// Embedded DockerRelayer so commands just work.
+	*relayer.DockerRelayer
+}
+
+func NewHermesRelayer(log *zap.Logger, testName, home string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *HermesRelayer {
+	c := newCommander(log, options...)
+	r := &HermesRelayer{
+		DockerRelayer: relayer.NewDockerRelayer(log, testName, home, cli, networkID, c, options...),
+	}
+
+	if err := os.MkdirAll(r.Dir(), 0755); err != nil {
+		panic(fmt.Errorf("failed to initialize directory for relayer: %w", err))
+	}
+
+	return r
+}
+
+// LocalHermesRelayer is the ibc.Relayer implementation for github.com/informalsystems/ibc-rs,
+// running a hermes binary on the host, such as one built from a local checkout.
+type LocalHermesRelayer struct {
+	// Embedded LocalRelayer so commands just work.
+	*relayer.LocalRelayer
+}
+
+// NewLocalHermesRelayer returns a LocalHermesRelayer that runs the hermes binary at bin.
+func NewLocalHermesRelayer(log *zap.Logger, testName, home, bin string, options ...relayer.RelayerOption) (*LocalHermesRelayer, error) {
+	c := newCommander(log, options...)
+
+	// The servers listen directly on the host, so they must not collide with other relayers.
+	var err error
+	if c.restPort, err = relayer.FreeLocalPort(); err != nil {
+		return nil, err
+	}
+	if c.telemetryPort, err = relayer.FreeLocalPort(); err != nil {
+		return nil, err
+	}
+
+	r, err := relayer.NewLocalRelayer(log, testName, home, bin, c, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalHermesRelayer{LocalRelayer: r}, nil
+}
+
+func newCommander(log *zap.Logger, options ...relayer.RelayerOption) *commander {
+	c := &commander{
+		log:   log,
+		paths: make(map[string]pathChains),
+
+		restPort:      defaultRestPort,
+		telemetryPort: defaultTelemetryPort,
+	}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionMetrics:
+			c.telemetry = true
+		}
+	}
+	return c
+}
+
+const (
+	DefaultContainerImage   = "ghcr.io/informalsystems/hermes"
+	DefaultContainerVersion = "1.0.0"
+)
+
+// Capabilities returns the set of capabilities of the Hermes relayer.
+//
+// Hermes packet filters apply to a chain rather than a path, so path packet filters are not supported.
+func Capabilities() map[relayer.Capability]bool {
+	m := relayer.FullCapabilities()
+	m[relayer.PacketFilter] = false
+	return m
+}
+
+// pathChains records the chain IDs associated with a path name.
+// Hermes has no concept of named paths, so the commander keeps track of them
+// in order to translate path-based commands into chain-based hermes commands.
+type pathChains struct {
+	Src, Dst string
+
+	// Delay period of the connections created on the path.
+	ConnectionDelay time.Duration
+}
+
+// commander satisfies relayer.RelayerCommander.
+type commander struct {
+	log             *zap.Logger
+	extraStartFlags []string
+
+	// Whether to enable the telemetry server, which serves the metrics.
+	telemetry bool
+
+	// Ports of the REST and telemetry servers.
+	restPort, telemetryPort string
+
+	mu    sync.Mutex
+	paths map[string]pathChains
+}
+
+func (*commander) Name() string {
+	return "hermes"
+}
+
+// configPath returns the path to the single hermes config file within homeDir.
+func configPath(homeDir string) string {
+	return path.Join(homeDir, "config.toml")
+}
+
+// hermesCmd returns the base hermes command with the config flag and JSON output set.
+func hermesCmd(homeDir string, args ...string) []string {
+	return append([]string{"hermes", "--config", configPath(homeDir), "--json"}, args...)
+}
+
+// shellScript joins the given command lines with && and wraps them in a single sh invocation.
+func shellScript(lines ...string) []string {
+	return []string{"sh", "-c", scriptBody(lines...)}
+}
+
+// scriptBody joins the given command lines with &&, so that they can be combined with other scripts.
+func scriptBody(lines ...string) string {
+	return strings.Join(lines, " && ")
+}
+
+// shellJoin quotes every argument of cmd so that it can be embedded in a shell script.
+func shellJoin(cmd []string) string {
+	quoted := make([]string, len(cmd))
+	for i, arg := range cmd {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote wraps s in single quotes, escaping any single quotes within s.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+func (c *commander) path(pathName string) pathChains {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	p, ok := c.paths[pathName]
+	if !ok {
+		// Every path-based method is preceded by GeneratePath,
+		// so a missing path is a programming error in the caller.
+		panic(fmt.Errorf("hermes: path %q was never generated", pathName))
+	}
+	return p
+}
+
+func (c *commander) Init(homeDir string) []string {
+	cfg := fmt.Sprintf(globalConfig, c.restPort, c.telemetryPort)
+	if c.telemetry {
+		cfg = strings.Replace(cfg, "[telemetry]\nenabled = false", "[telemetry]\nenabled = true", 1)
+	}
+	return []string{
+		"sh", "-c",
+		fmt.Sprintf("cat > %s <<'EOF'\n%sEOF", shellQuote(configPath(homeDir)), cfg),
+	}
+}
+
+func (*commander) AddChainConfiguration(containerFilePath, homeDir string) []string {
+	// Hermes reads every chain from a single config file,
+	// so append the chain's section to the file created during Init.
+	return shellScript(fmt.Sprintf("cat %s >> %s", shellQuote(containerFilePath), shellQuote(configPath(homeDir))))
+}
+
+func (*commander) AddKey(chainID, keyName, homeDir string) ([]string, error) {
+	// Hermes cannot generate keys on its own, so generate a mnemonic here,
+	// restore it in hermes, and print it so that ParseAddKeyOutput can report it.
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate entropy for mnemonic: %w", err)
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate mnemonic: %w", err)
+	}
+
+	mnemonicFile := mnemonicPath(homeDir, chainID, keyName)
+	return shellScript(
+		fmt.Sprintf("echo %s > %s", shellQuote(mnemonic), shellQuote(mnemonicFile)),
+		shellJoin(restoreKeyCmd(chainID, keyName, mnemonicFile, homeDir)),
+		"echo "+shellQuote(mnemonic),
+	), nil
+}
+
+func (*commander) RestoreKey(chainID, keyName, mnemonic, homeDir string) []string {
+	mnemonicFile := mnemonicPath(homeDir, chainID, keyName)
+	return shellScript(
+		fmt.Sprintf("echo %s > %s", shellQuote(mnemonic), shellQuote(mnemonicFile)),
+		shellJoin(restoreKeyCmd(chainID, keyName, mnemonicFile, homeDir)),
+	)
+}
+
+func mnemonicPath(homeDir, chainID, keyName string) string {
+	return path.Join(homeDir, fmt.Sprintf("mnemonic-%s-%s.txt", chainID, keyName))
+}
+
+func restoreKeyCmd(chainID, keyName, mnemonicFile, homeDir string) []string {
+	return hermesCmd(homeDir,
+		"keys", "add",
+		"--chain", chainID,
+		"--key-name", keyName,
+		"--mnemonic-file", mnemonicFile,
+		"--overwrite",
+	)
+}
+
+func (c *commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string) []string {
+	c.mu.Lock()
+	c.paths[pathName] = pathChains{Src: srcChainID, Dst: dstChainID}
+	c.mu.Unlock()
+
+	// Hermes has no path configuration, but confirm that both chains are configured.
+	return shellScript(
+		shellJoin(hermesCmd(homeDir, "query", "channels", "--chain", srcChainID)),
+		shellJoin(hermesCmd(homeDir, "query", "channels", "--chain", dstChainID)),
+	)
+}
+
+func (c *commander) GenerateSharedPath(pathName string, src, dst ibc.PathEnd, containerFilePath, homeDir string) []string {
+	c.mu.Lock()
+	c.paths[pathName] = pathChains{Src: src.ChainID, Dst: dst.ChainID}
+	c.mu.Unlock()
+
+	// Hermes relays every channel between the configured chains,
+	// so there is nothing to configure, but confirm that the connection exists on both chains.
+	return shellScript(
+		shellJoin(hermesCmd(homeDir, "query", "connection", "end", "--chain", src.ChainID, "--connection", src.ConnectionID)),
+		shellJoin(hermesCmd(homeDir, "query", "connection", "end", "--chain", dst.ChainID, "--connection", dst.ConnectionID)),
+	)
+}
+
+func (c *commander) CreateClients(pathName, homeDir string) []string {
+	return shellScript(createClientsScript(homeDir, c.path(pathName)))
+}
+
+// createClientsScript returns a shell snippet that creates a client on each chain of p tracking the other.
+func createClientsScript(homeDir string, p pathChains) string {
+	return scriptBody(
+		shellJoin(hermesCmd(homeDir, "create", "client", "--host-chain", p.Dst, "--reference-chain", p.Src)),
+		shellJoin(hermesCmd(homeDir, "create", "client", "--host-chain", p.Src, "--reference-chain", p.Dst)),
+	)
+}
+
+func (c *commander) CreateConnections(pathName, homeDir string) []string {
+	return shellScript(createConnectionsScript(homeDir, c.path(pathName)))
+}
+
+// createConnectionsScript returns a shell snippet that creates a connection between the chains of p,
+// on the most recently created clients, i.e. the ones from createClientsScript.
+func createConnectionsScript(homeDir string, p pathChains) string {
+	create := []string{"create", "connection", "--a-chain", p.Src}
+	if p.ConnectionDelay > 0 {
+		// Hermes takes the delay in whole seconds.
+		secs := int64((p.ConnectionDelay + time.Second - 1) / time.Second)
+		create = append(create, "--delay", strconv.FormatInt(secs, 10))
+	}
+	return scriptBody(
+		"a=$("+latestClientScript(homeDir, p.Src, p.Dst)+")",
+		"b=$("+latestClientScript(homeDir, p.Dst, p.Src)+")",
+		shellJoin(hermesCmd(homeDir, create...))+` --a-client "$a" --b-client "$b"`,
+	)
+}
+
+// latestClientScript returns a shell pipeline that prints the most recently created
+// client on hostChainID that tracks referenceChainID.
+func latestClientScript(homeDir, hostChainID, referenceChainID string) string {
+	query := shellJoin(hermesCmd(homeDir, "query", "clients", "--host-chain", hostChainID, "--reference-chain", referenceChainID))
+	return query + ` | grep -o '"client_id":"[^"]*"' | cut -d'"' -f4 | sort -t- -k3 -n | tail -n 1`
+}
+
+func (c *commander) CreateChannel(pathName string, opts ibc.CreateChannelOptions, homeDir string) []string {
+	return shellScript(createChannelScript(homeDir, c.path(pathName), opts))
+}
+
+// createChannelScript returns a shell snippet that creates a channel between the chains of p,
+// on the most recently created connection, i.e. the one from createConnectionsScript.
+func createChannelScript(homeDir string, p pathChains, opts ibc.CreateChannelOptions) string {
+	query := shellJoin(hermesCmd(homeDir, "query", "connections", "--chain", p.Src))
+	return scriptBody(
+		"conn=$("+query+` | grep -o 'connection-[0-9]*' | sort -t- -k2 -n | tail -n 1)`,
+		shellJoin(hermesCmd(homeDir,
+			"create", "channel",
+			"--a-chain", p.Src,
+			"--a-port", opts.SourcePortName,
+			"--b-port", opts.DestPortName,
+			"--order", opts.Order.String(),
+			"--channel-version", opts.Version,
+		))+` --a-connection "$conn"`,
+	)
+}
+
+func (c *commander) CloseChannel(pathName, channelID, portID, homeDir string) []string {
+	p := c.path(pathName)
+	connectionEnd := shellJoin(hermesCmd(homeDir, "query", "connection", "end", "--chain", p.Src)) + ` --connection "$conn"`
+
+	// Hermes names the chain receiving each handshake message "dst",
+	// so the init step targets the path's source chain and the confirm step targets its destination.
+	closeInit := shellJoin(hermesCmd(homeDir,
+		"tx", "chan-close-init",
+		"--dst-chain", p.Src,
+		"--src-chain", p.Dst,
+		"--dst-port", portID,
+		"--dst-channel", channelID,
+	)) + ` --dst-connection "$conn" --src-port "$rport" --src-channel "$rchan"`
+	closeConfirm := shellJoin(hermesCmd(homeDir,
+		"tx", "chan-close-confirm",
+		"--dst-chain", p.Dst,
+		"--src-chain", p.Src,
+		"--src-port", portID,
+		"--src-channel", channelID,
+	)) + ` --dst-connection "$rconn" --dst-port "$rport" --dst-channel "$rchan"`
+
+	return shellScript(
+		channelEndScript(homeDir, p.Src, portID, channelID),
+		`conn=$(echo "$end" | grep -o 'connection-[0-9]*' | head -n 1)`,
+		"rconn=$("+connectionEnd+` | grep -o '"counterparty":{[^}]*}' | grep -o 'connection-[0-9]*')`,
+		// The source end may already be closed, e.g. after a timeout on an ordered channel.
+		`if echo "$end" | grep -q '"state":"Open"'; then `+closeInit+`; fi`,
+		closeConfirm,
+	)
+}
+
+func (c *commander) LinkPath(pathName, homeDir string, opts ibc.CreateChannelOptions) []string {
+	p := c.path(pathName)
+	if p.ConnectionDelay > 0 {
+		// Creating a channel on a new connection does not accept a delay period,
+		// so create the clients, the connection and the channel one after the other.
+		return shellScript(
+			createClientsScript(homeDir, p),
+			createConnectionsScript(homeDir, p),
+			createChannelScript(homeDir, p, opts),
+		)
+	}
+	return hermesCmd(homeDir,
+		"create", "channel",
+		"--a-chain", p.Src,
+		"--b-chain", p.Dst,
+		"--a-port", opts.SourcePortName,
+		"--b-port", opts.DestPortName,
+		"--order", opts.Order.String(),
+		"--channel-version", opts.Version,
+		"--new-client-connection",
+		"--yes",
+	)
+}
+
+func (c *commander) UpdateClients(pathName, homeDir string) []string {
+	p := c.path(pathName)
+	return shellScript(
+		updateClientsScript(homeDir, p.Src, p.Dst),
+		updateClientsScript(homeDir, p.Dst, p.Src),
+	)
+}
+
+func (c *commander) UpdatePath(pathName string, opts ibc.PathOptions, homeDir string) ([]string, error) {
+	// Hermes only supports packet filters per chain, in the chain's section of the config file,
+	// so a filter cannot be applied to a single path.
+	if opts.PacketFilter.Policy != ibc.NoPacketFilter {
+		return nil, errors.New("hermes does not support packet filters on paths")
+	}
+
+	c.mu.Lock()
+	p := c.paths[pathName]
+	p.ConnectionDelay = opts.ConnectionDelay
+	c.paths[pathName] = p
+	c.mu.Unlock()
+
+	return nil, nil
+}
+
+// updateClientsScript returns a shell snippet that updates every client on hostChainID tracking referenceChainID.
+func updateClientsScript(homeDir, hostChainID, referenceChainID string) string {
+	query := shellJoin(hermesCmd(homeDir, "query", "clients", "--host-chain", hostChainID, "--reference-chain", referenceChainID))
+	update := shellJoin(hermesCmd(homeDir, "update", "client", "--host-chain", hostChainID))
+	// The query is captured before listing its clients, so that a failed query fails the script.
+	return fmt.Sprintf(
+		`clients=$(%s) && for id in %s; do %s --client "$id" || exit 1; done`,
+		query, clientIDsScript, update,
+	)
+}
+
+// clientIDsScript is a shell snippet that lists the client IDs in $clients,
+// the output of a hermes query clients command.
+const clientIDsScript = `$(echo "$clients" | grep -o '"client_id":"[^"]*"' | cut -d'"' -f4)`
+
+// channelEndScript returns a shell snippet that queries the end of the given channel on chainID into $end,
+// and its counterparty's port and channel into $rport and $rchan.
+func channelEndScript(homeDir, chainID, portID, channelID string) string {
+	channelEnd := shellJoin(hermesCmd(homeDir, "query", "channel", "end", "--chain", chainID, "--port", portID, "--channel", channelID))
+	return scriptBody(
+		"end=$("+channelEnd+")",
+		`rport=$(echo "$end" | grep -o '"remote":{[^}]*}' | grep -o '"port_id":"[^"]*"' | cut -d'"' -f4)`,
+		`rchan=$(echo "$end" | grep -o '"remote":{[^}]*}' | grep -o '"channel_id":"[^"]*"' | cut -d'"' -f4)`,
+	)
+}
+
+func (c *commander) FlushAcknowledgements(pathName, channelID, portID, homeDir string) []string {
+	// Relay the acknowledgements written on each chain back to the chain that sent the packets.
+	// Hermes names the chain holding the acknowledgements "src".
+	p := c.path(pathName)
+	return shellScript(
+		channelEndScript(homeDir, p.Src, portID, channelID),
+		shellJoin(hermesCmd(homeDir,
+			"tx", "packet-ack",
+			"--dst-chain", p.Src,
+			"--src-chain", p.Dst,
+		))+` --src-port "$rport" --src-channel "$rchan"`,
+		shellJoin(hermesCmd(homeDir,
+			"tx", "packet-ack",
+			"--dst-chain", p.Dst,
+			"--src-chain", p.Src,
+			"--src-port", portID,
+			"--src-channel", channelID,
+		)),
+	)
+}
+
+func (c *commander) FlushPackets(pathName, channelID, portID, homeDir string) []string {
+	// Relay the packets sent on the channel in both directions.
+	p := c.path(pathName)
+	return shellScript(
+		channelEndScript(homeDir, p.Src, portID, channelID),
+		shellJoin(hermesCmd(homeDir,
+			"tx", "packet-recv",
+			"--dst-chain", p.Dst,
+			"--src-chain", p.Src,
+			"--src-port", portID,
+			"--src-channel", channelID,
+		)),
+		shellJoin(hermesCmd(homeDir,
+			"tx", "packet-recv",
+			"--dst-chain", p.Src,
+			"--src-chain", p.Dst,
+		))+` --src-port "$rport" --src-channel "$rchan"`,
+	)
+}
+
+func (*commander) GetChannels(chainID, homeDir string) []string {
+	return hermesCmd(homeDir, "query", "channels", "--chain", chainID, "--verbose")
+}
+
+func (*commander) GetClients(chainID, homeDir string) []string {
+	// Hermes only lists client IDs, so query each client's state,
+	// printing the client ID before the result line of each query.
+	// Each query is captured before its output is used, so that a failed query fails the script.
+	query := shellJoin(hermesCmd(homeDir, "query", "clients", "--host-chain", chainID))
+	state := shellJoin(hermesCmd(homeDir, "query", "client", "state", "--chain", chainID))
+	return shellScript(fmt.Sprintf(
+		`clients=$(%s) && for id in %s; do state=$(%s --client "$id") || exit 1; printf '%%s ' "$id"; echo "$state" | tail -n 1; done`,
+		query, clientIDsScript, state,
+	))
+}
+
+func (*commander) GetConnections(chainID, homeDir string) []string {
+	return hermesCmd(homeDir, "query", "connections", "--chain", chainID, "--verbose")
+}
+
+func (c *commander) StartRelayer(homeDir string, pathNames ...string) []string {
+	// Hermes relays on all configured chains; there is no per-path start command.
+	cmd := []string{"hermes", "--config", configPath(homeDir), "start"}
+	cmd = append(cmd, c.extraStartFlags...)
+	return cmd
+}
+
+func (*commander) SharedPathContent(src, dst ibc.PathEnd) ([]byte, error) {
+	// Hermes has no path configuration.
+	return nil, nil
+}
+
+func (*commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
+	return ChainConfigToHermesChainConfig(cfg, keyName, rpcAddr, grpcAddr)
+}
+
+func (c *commander) MetricsEndpoint() (port, path string) {
+	if !c.telemetry {
+		return "", ""
+	}
+	return c.telemetryPort + "/tcp", "/metrics"
+}
+
+func (*commander) DefaultContainerImage() string {
+	return DefaultContainerImage
+}
+
+func (*commander) DefaultContainerVersion() string {
+	return DefaultContainerVersion
+}
+
+func (*commander) ParseAddKeyOutput(stdout, stderr string) (ibc.RelayerWallet, error) {
+	return parseAddKeyOutput(stdout)
+}
+
+func (*commander) ParseRestoreKeyOutput(stdout, stderr string) string {
+	addr, _ := parseRestoredAddress(stdout)
+	return addr
+}
+
+func (c *commander) ParseGetChannelsOutput(stdout, stderr string) ([]ibc.ChannelOutput, error) {
+	return parseChannelsOutput(stdout)
+}
+
+func (c *commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.ConnectionOutputs, error) {
+	return parseConnectionsOutput(stdout)
+}
+
+func (c *commander) ParseGetClientsOutput(stdout, stderr string) ([]ibc.ClientOutput, error) {
+	return parseClientsOutput(stdout)
+}
@@ -0,0 +1,101 @@
+package hermes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newTestCommander returns a commander with the path p generated between chains a and b.
+func newTestCommander(t *testing.T, opts ibc.PathOptions) *commander {
+	t.Helper()
+
+	c := newCommander(zap.NewNop())
+	c.GeneratePath("a", "b", "p", "/home")
+	_, err := c.UpdatePath("p", opts, "/home")
+	require.NoError(t, err)
+	return c
+}
+
+func TestCommander_LinkPathWithDelay(t *testing.T) {
+	c := newTestCommander(t, ibc.PathOptions{ConnectionDelay: 1500 * time.Millisecond})
+	opts := ibc.DefaultChannelOpts()
+
+	// The combined script runs each step's script in order.
+	want := shellScript(
+		c.CreateClients("p", "/home")[2],
+		c.CreateConnections("p", "/home")[2],
+		c.CreateChannel("p", opts, "/home")[2],
+	)
+	require.Equal(t, want, c.LinkPath("p", "/home", opts))
+	require.Contains(t, want[2], `'--delay' '2'`)
+}
+
+// runWithFakeHermes runs cmd with a fake hermes binary first in PATH.
+// The fake hermes fails every command whose arguments contain fail,
+// and otherwise prints one client for client queries.
+func runWithFakeHermes(t *testing.T, cmd []string, fail string) (string, error) {
+	t.Helper()
+
+	dir := t.TempDir()
+	fake := `#!/bin/sh
+case "$*" in
+*` + fail + `*) echo "rpc error" >&2; exit 1 ;;
+*"query clients"*) echo '{"result":[{"client_id":"07-tendermint-0","chain_id":"b"}],"status":"success"}' ;;
+*"query client state"*) echo 'log line'; echo '{"result":{},"status":"success"}' ;;
+esac
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "hermes"), []byte(fake), 0o755))
+
+	c := exec.Command(cmd[0], cmd[1:]...)
+	c.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	out, err := c.Output()
+	return string(out), err
+}
+
+func TestCommander_ClientScriptsFail(t *testing.T) {
+	c := newTestCommander(t, ibc.PathOptions{})
+
+	out, err := runWithFakeHermes(t, c.GetClients("a", "/home"), "never")
+	require.NoError(t, err)
+	require.Equal(t, "07-tendermint-0 {\"result\":{},\"status\":\"success\"}\n", out)
+
+	_, err = runWithFakeHermes(t, c.GetClients("a", "/home"), "query clients")
+	require.Error(t, err, "failed client list must fail GetClients")
+
+	_, err = runWithFakeHermes(t, c.GetClients("a", "/home"), "query client state")
+	require.Error(t, err, "failed client state query must fail GetClients")
+
+	_, err = runWithFakeHermes(t, c.UpdateClients("p", "/home"), "never")
+	require.NoError(t, err)
+
+	_, err = runWithFakeHermes(t, c.UpdateClients("p", "/home"), "query clients")
+	require.Error(t, err, "failed client list must fail UpdateClients")
+
+	_, err = runWithFakeHermes(t, c.UpdateClients("p", "/home"), "update client")
+	require.Error(t, err, "failed client update must fail UpdateClients")
+}
+
+func TestCommander_Flush(t *testing.T) {
+	c := newTestCommander(t, ibc.PathOptions{})
+
+	packets := c.FlushPackets("p", "channel-0", "transfer", "/home")[2]
+	require.Contains(t, packets, `'tx' 'packet-recv'`)
+	require.NotContains(t, packets, `'packet-ack'`)
+
+	acks := c.FlushAcknowledgements("p", "channel-0", "transfer", "/home")[2]
+	require.Contains(t, acks, `'tx' 'packet-ack'`)
+	require.NotContains(t, acks, `'packet-recv'`)
+
+	// Both directions of the channel are relayed.
+	for _, script := range []string{packets, acks} {
+		require.Contains(t, script, `'--src-port' 'transfer' '--src-channel' 'channel-0'`)
+		require.Contains(t, script, `--src-port "$rport" --src-channel "$rchan"`)
+	}
+}
@@ -0,0 +1,264 @@
+package hermes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// jsonResult is the final line hermes prints when run with --json.
+type jsonResult struct {
+	Result json.RawMessage `json:"result"`
+	Status string          `json:"status"`
+}
+
+// parseResult finds the result line in hermes' JSON output and decodes its result into v.
+// Hermes also emits JSON log lines on stdout, which are skipped.
+func parseResult(stdout string, v interface{}) error {
+	lines := strings.Split(stdout, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+
+		var res jsonResult
+		if err := json.Unmarshal([]byte(line), &res); err != nil || res.Status == "" {
+			continue
+		}
+		if res.Status != "success" {
+			return fmt.Errorf("hermes returned status %q: %s", res.Status, res.Result)
+		}
+		return json.Unmarshal(res.Result, v)
+	}
+
+	return errors.New("no result found in hermes output")
+}
+
+// restoredAddressRe matches the address in hermes' "Restored key" message.
+var restoredAddressRe = regexp.MustCompile(`Restored key '[^']*' \((\S+)\)`)
+
+// parseRestoredAddress returns the address reported by a hermes keys add command.
+func parseRestoredAddress(stdout string) (string, error) {
+	m := restoredAddressRe.FindStringSubmatch(stdout)
+	if m == nil {
+		return "", errors.New("no restored key address found in hermes output")
+	}
+	return m[1], nil
+}
+
+// parseAddKeyOutput parses the output of the AddKey command,
+// which is the output of hermes keys add followed by the mnemonic on its own line.
+func parseAddKeyOutput(stdout string) (ibc.RelayerWallet, error) {
+	var wallet ibc.RelayerWallet
+
+	addr, err := parseRestoredAddress(stdout)
+	if err != nil {
+		return wallet, err
+	}
+	wallet.Address = addr
+
+	lines := strings.Split(strings.TrimSpace(stdout), "\n")
+	wallet.Mnemonic = strings.TrimSpace(lines[len(lines)-1])
+	if wallet.Mnemonic == "" || strings.Contains(wallet.Mnemonic, "{") {
+		return wallet, errors.New("no mnemonic found in hermes output")
+	}
+
+	return wallet, nil
+}
+
+// hermesChannel is a single entry in the result of hermes query channels --verbose.
+type hermesChannel struct {
+	PortID     string `json:"port_id"`
+	ChannelID  string `json:"channel_id"`
+	ChannelEnd struct {
+		State    string `json:"state"`
+		Ordering string `json:"ordering"`
+		Remote   struct {
+			PortID    string `json:"port_id"`
+			ChannelID string `json:"channel_id"`
+		} `json:"remote"`
+		ConnectionHops []string `json:"connection_hops"`
+		Version        string   `json:"version"`
+	} `json:"channel_end"`
+}
+
+func parseChannelsOutput(stdout string) ([]ibc.ChannelOutput, error) {
+	var res []hermesChannel
+	if err := parseResult(stdout, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse channels: %w", err)
+	}
+
+	channels := make([]ibc.ChannelOutput, len(res))
+	for i, ch := range res {
+		channels[i] = ibc.ChannelOutput{
+			State:    channelState(ch.ChannelEnd.State),
+			Ordering: channelOrder(ch.ChannelEnd.Ordering),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.ChannelEnd.Remote.PortID,
+				ChannelID: ch.ChannelEnd.Remote.ChannelID,
+			},
+			ConnectionHops: ch.ChannelEnd.ConnectionHops,
+			Version:        ch.ChannelEnd.Version,
+			PortID:         ch.PortID,
+			ChannelID:      ch.ChannelID,
+		}
+	}
+	return channels, nil
+}
+
+// hermesConnection is a single entry in the result of hermes query connections --verbose.
+type hermesConnection struct {
+	ConnectionID  string `json:"connection_id"`
+	ConnectionEnd struct {
+		ClientID string `json:"client_id"`
+		Versions []struct {
+			Identifier string   `json:"identifier"`
+			Features   []string `json:"features"`
+		} `json:"versions"`
+		State        string `json:"state"`
+		Counterparty struct {
+			ClientID     string `json:"client_id"`
+			ConnectionID string `json:"connection_id"`
+			Prefix       string `json:"prefix"`
+		} `json:"counterparty"`
+		DelayPeriod struct {
+			Secs  uint64 `json:"secs"`
+			Nanos uint64 `json:"nanos"`
+		} `json:"delay_period"`
+	} `json:"connection_end"`
+}
+
+func parseConnectionsOutput(stdout string) (ibc.ConnectionOutputs, error) {
+	var res []hermesConnection
+	if err := parseResult(stdout, &res); err != nil {
+		return nil, fmt.Errorf("failed to parse connections: %w", err)
+	}
+
+	connections := make(ibc.ConnectionOutputs, len(res))
+	for i, conn := range res {
+		end := conn.ConnectionEnd
+
+		versions := make([]*conntypes.Version, len(end.Versions))
+		for j, v := range end.Versions {
+			versions[j] = conntypes.NewVersion(v.Identifier, v.Features)
+		}
+
+		connections[i] = &ibc.ConnectionOutput{
+			ID:       conn.ConnectionID,
+			ClientID: end.ClientID,
+			Versions: versions,
+			State:    connectionState(end.State),
+			Counterparty: &conntypes.Counterparty{
+				ClientId:     end.Counterparty.ClientID,
+				ConnectionId: end.Counterparty.ConnectionID,
+				Prefix:       commitmenttypes.NewMerklePrefix([]byte(end.Counterparty.Prefix)),
+			},
+			DelayPeriod: fmt.Sprint(end.DelayPeriod.Secs*1e9 + end.DelayPeriod.Nanos),
+		}
+	}
+	return connections, nil
+}
+
+// hermesHeight is an IBC height as printed by hermes.
+type hermesHeight struct {
+	RevisionNumber uint64 `json:"revision_number"`
+	RevisionHeight uint64 `json:"revision_height"`
+}
+
+// hermesClientState is the result of hermes query client state, for a tendermint client.
+type hermesClientState struct {
+	ChainID        string `json:"chain_id"`
+	TrustingPeriod struct {
+		Secs  uint64 `json:"secs"`
+		Nanos uint64 `json:"nanos"`
+	} `json:"trusting_period"`
+	LatestHeight hermesHeight  `json:"latest_height"`
+	FrozenHeight *hermesHeight `json:"frozen_height"`
+}
+
+// parseClientsOutput parses the output of the GetClients command,
+// in which each line is a client ID followed by the result line of querying that client's state.
+func parseClientsOutput(stdout string) ([]ibc.ClientOutput, error) {
+	var clients []ibc.ClientOutput
+	for _, line := range strings.Split(stdout, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("malformed client line %q", line)
+		}
+		clientID := line[:i]
+
+		var cs hermesClientState
+		if err := parseResult(line[i+1:], &cs); err != nil {
+			return nil, fmt.Errorf("failed to parse client state for %s: %w", clientID, err)
+		}
+
+		clients = append(clients, ibc.ClientOutput{
+			ID:             clientID,
+			ChainID:        cs.ChainID,
+			TrustingPeriod: time.Duration(cs.TrustingPeriod.Secs)*time.Second + time.Duration(cs.TrustingPeriod.Nanos),
+			LatestHeight:   clienttypes.NewHeight(cs.LatestHeight.RevisionNumber, cs.LatestHeight.RevisionHeight),
+			Frozen:         cs.FrozenHeight != nil && (cs.FrozenHeight.RevisionNumber != 0 || cs.FrozenHeight.RevisionHeight != 0),
+		})
+	}
+	return clients, nil
+}
+
+// channelState converts a hermes channel state such as "Open"
+// to the string representation used by ibc-go, such as "STATE_OPEN".
+func channelState(s string) string {
+	switch strings.ToLower(s) {
+	case "init":
+		return chantypes.INIT.String()
+	case "tryopen":
+		return chantypes.TRYOPEN.String()
+	case "open":
+		return chantypes.OPEN.String()
+	case "closed":
+		return chantypes.CLOSED.String()
+	default:
+		return chantypes.UNINITIALIZED.String()
+	}
+}
+
+// channelOrder converts a hermes channel ordering such as "Unordered"
+// to the string representation used by ibc-go, such as "ORDER_UNORDERED".
+func channelOrder(s string) string {
+	switch strings.ToLower(s) {
+	case "ordered":
+		return chantypes.ORDERED.String()
+	case "unordered":
+		return chantypes.UNORDERED.String()
+	default:
+		return chantypes.NONE.String()
+	}
+}
+
+// connectionState converts a hermes connection state such as "Open"
+// to the string representation used by ibc-go, such as "STATE_OPEN".
+func connectionState(s string) string {
+	switch strings.ToLower(s) {
+	case "init":
+		return conntypes.INIT.String()
+	case "tryopen":
+		return conntypes.TRYOPEN.String()
+	case "open":
+		return conntypes.OPEN.String()
+	default:
+		return conntypes.UNINITIALIZED.String()
+	}
+}
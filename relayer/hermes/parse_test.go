@@ -0,0 +1,76 @@
+package hermes
+
+import (
+	"testing"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddKeyOutput(t *testing.T) {
+	const stdout = `{"timestamp":"Jun 01 00:00:00.000","level":"INFO","fields":{"message":"using default configuration"}}
+{"result":"Restored key 'relayer' (cosmos1abc) on chain ibc-0","status":"success"}
+abandon abandon about
+`
+	wallet, err := parseAddKeyOutput(stdout)
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1abc", wallet.Address)
+	require.Equal(t, "abandon abandon about", wallet.Mnemonic)
+
+	_, err = parseAddKeyOutput(`{"result":"no such chain","status":"error"}`)
+	require.Error(t, err)
+}
+
+func TestParseChannelsOutput(t *testing.T) {
+	const stdout = `{"timestamp":"Jun 01 00:00:00.000","level":"INFO","fields":{"message":"using default configuration"}}
+{"result":[{"port_id":"transfer","channel_id":"channel-0","channel_end":{"state":"Open","ordering":"Unordered","remote":{"port_id":"transfer","channel_id":"channel-1"},"connection_hops":["connection-0"],"version":"ics20-1"}}],"status":"success"}
+`
+	channels, err := parseChannelsOutput(stdout)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+
+	ch := channels[0]
+	require.Equal(t, "STATE_OPEN", ch.State)
+	require.Equal(t, "ORDER_UNORDERED", ch.Ordering)
+	require.Equal(t, "channel-0", ch.ChannelID)
+	require.Equal(t, "channel-1", ch.Counterparty.ChannelID)
+	require.Equal(t, []string{"connection-0"}, ch.ConnectionHops)
+
+	_, err = parseChannelsOutput(`{"result":"chain not found","status":"error"}`)
+	require.Error(t, err)
+}
+
+func TestParseConnectionsOutput(t *testing.T) {
+	const stdout = `{"result":[{"connection_id":"connection-0","connection_end":{"client_id":"07-tendermint-0","versions":[{"identifier":"1","features":["ORDER_ORDERED","ORDER_UNORDERED"]}],"state":"Open","counterparty":{"client_id":"07-tendermint-1","connection_id":"connection-2","prefix":"ibc"},"delay_period":{"secs":0,"nanos":0}}}],"status":"success"}`
+
+	conns, err := parseConnectionsOutput(stdout)
+	require.NoError(t, err)
+	require.Len(t, conns, 1)
+
+	conn := conns[0]
+	require.Equal(t, "connection-0", conn.ID)
+	require.Equal(t, "07-tendermint-0", conn.ClientID)
+	require.Equal(t, "STATE_OPEN", conn.State)
+	require.Equal(t, "connection-2", conn.Counterparty.ConnectionId)
+	require.Equal(t, "0", conn.DelayPeriod)
+}
+
+func TestParseClientsOutput(t *testing.T) {
+	const stdout = `07-tendermint-0 {"result":{"type":"Tendermint","chain_id":"chain-b","trusting_period":{"secs":3600,"nanos":0},"latest_height":{"revision_number":1,"revision_height":42},"frozen_height":null},"status":"success"}
+07-tendermint-1 {"result":{"type":"Tendermint","chain_id":"chain-b","trusting_period":{"secs":3600,"nanos":0},"latest_height":{"revision_number":1,"revision_height":42},"frozen_height":{"revision_number":0,"revision_height":1}},"status":"success"}
+`
+
+	clients, err := parseClientsOutput(stdout)
+	require.NoError(t, err)
+	require.Len(t, clients, 2)
+
+	require.Equal(t, "07-tendermint-0", clients[0].ID)
+	require.Equal(t, "chain-b", clients[0].ChainID)
+	require.Equal(t, time.Hour, clients[0].TrustingPeriod)
+	require.Equal(t, clienttypes.NewHeight(1, 42), clients[0].LatestHeight)
+	require.False(t, clients[0].Frozen)
+
+	require.Equal(t, "07-tendermint-1", clients[1].ID)
+	require.True(t, clients[1].Frozen)
+}
@@ -0,0 +1,472 @@
+package inprocess
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	"github.com/cosmos/cosmos-sdk/simapp"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	"github.com/cosmos/ibc-go/v3/modules/core/exported"
+	ibctypes "github.com/cosmos/ibc-go/v3/modules/core/types"
+	tmclient "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	coretypes "github.com/tendermint/tendermint/rpc/core/types"
+	libclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newEncoding() simappparams.EncodingConfig {
+	cfg := simappparams.MakeTestEncodingConfig()
+	std.RegisterLegacyAminoCodec(cfg.Amino)
+	std.RegisterInterfaces(cfg.InterfaceRegistry)
+	simapp.ModuleBasics.RegisterLegacyAminoCodec(cfg.Amino)
+	simapp.ModuleBasics.RegisterInterfaces(cfg.InterfaceRegistry)
+
+	ibctypes.RegisterInterfaces(cfg.InterfaceRegistry)
+
+	return cfg
+}
+
+var defaultEncoding = newEncoding()
+
+// chain is the relayer's connection to a single chain,
+// through the chain's Tendermint RPC and Cosmos SDK gRPC endpoints.
+type chain struct {
+	log *zap.Logger
+
+	cfg     ibc.ChainConfig
+	keyName string
+
+	rpc  *rpchttp.HTTP
+	grpc *grpc.ClientConn
+
+	kr keyring.Keyring
+
+	// txMu serializes transactions from the relayer wallet,
+	// so that concurrent callers do not reuse an account sequence.
+	txMu sync.Mutex
+}
+
+func newChain(log *zap.Logger, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (*chain, error) {
+	httpClient, err := libclient.DefaultHTTPClient(rpcAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client for %s: %w", rpcAddr, err)
+	}
+	httpClient.Timeout = 10 * time.Second
+	rpc, err := rpchttp.NewWithClient(rpcAddr, "/websocket", httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rpc client for %s: %w", rpcAddr, err)
+	}
+
+	conn, err := grpc.Dial(grpcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial grpc at %s: %w", grpcAddr, err)
+	}
+
+	return &chain{
+		log: log.With(zap.String("chain_id", cfg.ChainID)),
+
+		cfg:     cfg,
+		keyName: keyName,
+
+		rpc:  rpc,
+		grpc: conn,
+
+		kr: keyring.NewInMemory(),
+	}, nil
+}
+
+// restoreKey adds the key derived from mnemonic to the chain's keyring and returns its address.
+func (c *chain) restoreKey(keyName, mnemonic string) (string, error) {
+	info, err := c.kr.NewAccount(keyName, mnemonic, "", hd.CreateHDPath(sdk.CoinType, 0, 0).String(), hd.Secp256k1)
+	if err != nil {
+		return "", err
+	}
+	return sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+}
+
+// addKey creates a new key in the chain's keyring and returns its wallet.
+func (c *chain) addKey(keyName string) (ibc.RelayerWallet, error) {
+	info, mnemonic, err := c.kr.NewMnemonic(keyName, keyring.English, hd.CreateHDPath(sdk.CoinType, 0, 0).String(), "", hd.Secp256k1)
+	if err != nil {
+		return ibc.RelayerWallet{}, err
+	}
+	addr, err := sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+	if err != nil {
+		return ibc.RelayerWallet{}, err
+	}
+	return ibc.RelayerWallet{Mnemonic: mnemonic, Address: addr}, nil
+}
+
+// signer returns the bech32 address of the relayer's key on this chain.
+func (c *chain) signer() (string, error) {
+	info, err := c.kr.Key(c.keyName)
+	if err != nil {
+		return "", fmt.Errorf("relayer key %q not found for chain %s: %w", c.keyName, c.cfg.ChainID, err)
+	}
+	return sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+}
+
+// status returns the latest block height and time of the chain.
+func (c *chain) status(ctx context.Context) (int64, time.Time, error) {
+	stat, err := c.rpc.Status(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return stat.SyncInfo.LatestBlockHeight, stat.SyncInfo.LatestBlockTime, nil
+}
+
+// waitForHeightAbove blocks until the chain's latest height is greater than h,
+// returning the latest height.
+func (c *chain) waitForHeightAbove(ctx context.Context, h int64) (int64, error) {
+	for {
+		latest, _, err := c.status(ctx)
+		if err != nil {
+			return 0, err
+		}
+		if latest > h {
+			return latest, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// ibcHeight converts a block height on this chain into an IBC height.
+func (c *chain) ibcHeight(h int64) clienttypes.Height {
+	return clienttypes.NewHeight(clienttypes.ParseChainID(c.cfg.ChainID), uint64(h))
+}
+
+// header returns the light client header for height h,
+// without the trusted height and trusted validators set.
+func (c *chain) header(ctx context.Context, h int64) (*tmclient.Header, error) {
+	commit, err := c.rpc.Commit(ctx, &h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit at height %d: %w", h, err)
+	}
+
+	valSet, err := c.validatorSet(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tmclient.Header{
+		SignedHeader: commit.SignedHeader.ToProto(),
+		ValidatorSet: valSet,
+	}, nil
+}
+
+// validatorSet returns the validator set at height h.
+func (c *chain) validatorSet(ctx context.Context, h int64) (*tmproto.ValidatorSet, error) {
+	var vals []*tmtypes.Validator
+	page, perPage := 1, 100
+	for {
+		res, err := c.rpc.Validators(ctx, &h, &page, &perPage)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get validators at height %d: %w", h, err)
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			break
+		}
+		page++
+	}
+
+	return tmtypes.NewValidatorSet(vals).ToProto()
+}
+
+// clientState returns the current state of the given client on this chain.
+func (c *chain) clientState(ctx context.Context, clientID string) (exported.ClientState, error) {
+	res, err := clienttypes.NewQueryClient(c.grpc).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client state for %s: %w", clientID, err)
+	}
+	return clienttypes.UnpackClientState(res.ClientState)
+}
+
+// unbondingPeriod returns the staking unbonding period of the chain.
+func (c *chain) unbondingPeriod(ctx context.Context) (time.Duration, error) {
+	res, err := stakingtypes.NewQueryClient(c.grpc).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to query staking params: %w", err)
+	}
+	return res.Params.UnbondingTime, nil
+}
+
+// queryProof returns the value stored under key in the IBC store,
+// along with a proof that is verifiable against the header at proofHeight.
+func (c *chain) queryProof(ctx context.Context, key []byte, proofHeight int64) ([]byte, []byte, error) {
+	// The app hash committing to the state after block h is included in the header of block h+1.
+	res, err := c.rpc.ABCIQueryWithOptions(ctx, "store/ibc/key", key, rpcclient.ABCIQueryOptions{
+		Height: proofHeight - 1,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query %q: %w", key, err)
+	}
+	if res.Response.Code != 0 {
+		return nil, nil, fmt.Errorf("failed to query %q: %s", key, res.Response.Log)
+	}
+
+	merkleProof, err := commitmenttypes.ConvertProofs(res.Response.ProofOps)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to convert proof for %q: %w", key, err)
+	}
+	proof, err := defaultEncoding.Marshaler.Marshal(&merkleProof)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return res.Response.Value, proof, nil
+}
+
+// sendMsgs signs and broadcasts a transaction containing msgs,
+// and waits for the transaction to be included in a block.
+func (c *chain) sendMsgs(ctx context.Context, msgs ...sdk.Msg) (*coretypes.ResultTx, error) {
+	c.txMu.Lock()
+	defer c.txMu.Unlock()
+
+	info, err := c.kr.Key(c.keyName)
+	if err != nil {
+		return nil, fmt.Errorf("relayer key %q not found for chain %s: %w", c.keyName, c.cfg.ChainID, err)
+	}
+	addr, err := sdk.Bech32ifyAddressBytes(c.cfg.Bech32Prefix, info.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	accNum, seq, err := c.account(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	gasPrices, err := sdk.ParseDecCoins(c.cfg.GasPrices)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas prices %q: %w", c.cfg.GasPrices, err)
+	}
+
+	txCfg := defaultEncoding.TxConfig
+	builder := txCfg.NewTxBuilder()
+	if err := builder.SetMsgs(msgs...); err != nil {
+		return nil, err
+	}
+
+	// Simulate with an empty signature to determine the gas to request.
+	if err := builder.SetSignatures(signing.SignatureV2{
+		PubKey:   &secp256k1.PubKey{},
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		Sequence: seq,
+	}); err != nil {
+		return nil, err
+	}
+	simBz, err := txCfg.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	sim, err := txtypes.NewServiceClient(c.grpc).Simulate(ctx, &txtypes.SimulateRequest{TxBytes: simBz})
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate tx: %w", err)
+	}
+
+	gasAdjustment := c.cfg.GasAdjustment
+	if gasAdjustment < 1 {
+		gasAdjustment = 1
+	}
+	gas := uint64(gasAdjustment * float64(sim.GasInfo.GasUsed))
+
+	fees := make(sdk.Coins, 0, len(gasPrices))
+	for _, gp := range gasPrices {
+		fees = append(fees, sdk.NewCoin(gp.Denom, gp.Amount.MulInt64(int64(gas)).Ceil().RoundInt()))
+	}
+	builder.SetGasLimit(gas)
+	builder.SetFeeAmount(sdk.NewCoins(fees...))
+
+	// Sign manually rather than through the SDK's client/tx package,
+	// as that path resolves signers with the global bech32 prefix,
+	// which does not match every chain under test.
+	sig := signing.SignatureV2{
+		PubKey:   info.GetPubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		Sequence: seq,
+	}
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+	signBytes, err := txCfg.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, authsigning.SignerData{
+		ChainID:       c.cfg.ChainID,
+		AccountNumber: accNum,
+		Sequence:      seq,
+	}, builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	sigBytes, _, err := c.kr.Sign(c.keyName, signBytes)
+	if err != nil {
+		return nil, err
+	}
+	sig.Data = &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT, Signature: sigBytes}
+	if err := builder.SetSignatures(sig); err != nil {
+		return nil, err
+	}
+
+	txBz, err := txCfg.TxEncoder()(builder.GetTx())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.rpc.BroadcastTxSync(ctx, txBz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to broadcast tx: %w", err)
+	}
+	if res.Code != 0 {
+		return nil, fmt.Errorf("tx failed check with code %d: %s", res.Code, res.Log)
+	}
+
+	return c.waitForTx(ctx, res.Hash)
+}
+
+// account returns the account number and sequence for addr.
+func (c *chain) account(ctx context.Context, addr string) (uint64, uint64, error) {
+	res, err := authtypes.NewQueryClient(c.grpc).Account(ctx, &authtypes.QueryAccountRequest{Address: addr})
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to query account %s: %w", addr, err)
+	}
+
+	var acc authtypes.AccountI
+	if err := defaultEncoding.InterfaceRegistry.UnpackAny(res.Account, &acc); err != nil {
+		return 0, 0, fmt.Errorf("failed to unpack account %s: %w", addr, err)
+	}
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
+
+// waitForTx polls for the transaction with the given hash until it is included in a block.
+func (c *chain) waitForTx(ctx context.Context, hash []byte) (*coretypes.ResultTx, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	for {
+		res, err := c.rpc.Tx(ctx, hash, false)
+		if err == nil {
+			if res.TxResult.Code != 0 {
+				return res, fmt.Errorf("tx %X failed with code %d: %s", hash, res.TxResult.Code, res.TxResult.Log)
+			}
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("tx %X was not included in a block: %w", hash, ctx.Err())
+		case <-time.After(250 * time.Millisecond):
+		}
+	}
+}
+
+// searchEvents returns every event of type eventType, across transactions matching the query,
+// whose attributes include every key-value pair in attrs.
+func (c *chain) searchEvents(ctx context.Context, eventType string, attrs map[string]string) ([]map[string]string, error) {
+	conds := make([]string, 0, len(attrs))
+	for k, v := range attrs {
+		conds = append(conds, fmt.Sprintf("%s.%s='%s'", eventType, k, v))
+	}
+
+	var out []map[string]string
+	page, perPage, seen := 1, 100, 0
+	for {
+		res, err := c.rpc.TxSearch(ctx, strings.Join(conds, " AND "), false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, fmt.Errorf("failed to search for %s events: %w", eventType, err)
+		}
+		for _, tx := range res.Txs {
+			for _, ev := range eventAttributes(tx.TxResult.Events, eventType) {
+				if matchesAll(ev, attrs) {
+					out = append(out, ev)
+				}
+			}
+		}
+		seen += len(res.Txs)
+		if seen >= res.TotalCount || len(res.Txs) == 0 {
+			break
+		}
+		page++
+	}
+	return out, nil
+}
+
+// eventAttributes returns the attributes of every event of type eventType.
+func eventAttributes(events []abci.Event, eventType string) []map[string]string {
+	var out []map[string]string
+	for _, ev := range events {
+		if ev.Type != eventType {
+			continue
+		}
+		attrs := make(map[string]string, len(ev.Attributes))
+		for _, attr := range ev.Attributes {
+			attrs[string(attr.Key)] = string(attr.Value)
+		}
+		out = append(out, attrs)
+	}
+	return out
+}
+
+// eventAttribute returns the value of the first attribute named key in an event of type eventType.
+func eventAttribute(events []abci.Event, eventType, key string) (string, error) {
+	for _, attrs := range eventAttributes(events, eventType) {
+		if v, ok := attrs[key]; ok {
+			return v, nil
+		}
+	}
+	return "", fmt.Errorf("no %s attribute found in %s event", key, eventType)
+}
+
+func matchesAll(attrs, want map[string]string) bool {
+	for k, v := range want {
+		if attrs[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeEventBytes returns the bytes of the attribute named key,
+// preferring the hex encoded variant of the attribute when present.
+func decodeEventBytes(attrs map[string]string, key string) ([]byte, error) {
+	if v, ok := attrs[key+"_hex"]; ok {
+		return hex.DecodeString(v)
+	}
+	if v, ok := attrs[key]; ok {
+		return []byte(v), nil
+	}
+	return nil, errors.New("missing event attribute " + key)
+}
+
+// allPages is a page request large enough to cover the state of any test chain.
+var allPages = &query.PageRequest{Limit: 10_000}
@@ -0,0 +1,425 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+	tmclient "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// maxClockDrift is the clock drift allowed by clients created by the relayer.
+const maxClockDrift = 10 * time.Second
+
+// defaultUpgradePath is the upgrade path of Cosmos SDK chains, which clients must match.
+var defaultUpgradePath = []string{"upgrade", "upgradedIBCState"}
+
+// ibcPrefix is the commitment prefix of the IBC store on Cosmos SDK chains.
+var ibcPrefix = commitmenttypes.NewMerklePrefix([]byte("ibc"))
+
+// createClient creates a client on dst that tracks src, returning the new client ID.
+func createClient(ctx context.Context, src, dst *chain) (string, error) {
+	h, _, err := src.status(ctx)
+	if err != nil {
+		return "", err
+	}
+	hdr, err := src.header(ctx, h)
+	if err != nil {
+		return "", err
+	}
+
+	unbondingPeriod, err := src.unbondingPeriod(ctx)
+	if err != nil {
+		return "", err
+	}
+	trustingPeriod, err := clientTrustingPeriod(src.cfg.TrustingPeriod, unbondingPeriod)
+	if err != nil {
+		return "", err
+	}
+
+	cs := tmclient.NewClientState(
+		src.cfg.ChainID, tmclient.DefaultTrustLevel,
+		trustingPeriod, unbondingPeriod, maxClockDrift,
+		src.ibcHeight(h), commitmenttypes.GetSDKSpecs(),
+		defaultUpgradePath, false, false,
+	)
+
+	signer, err := dst.signer()
+	if err != nil {
+		return "", err
+	}
+	msg, err := clienttypes.NewMsgCreateClient(cs, hdr.ConsensusState(), signer)
+	if err != nil {
+		return "", err
+	}
+
+	res, err := dst.sendMsgs(ctx, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create client on %s: %w", dst.cfg.ChainID, err)
+	}
+	return eventAttribute(res.TxResult.Events, clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID)
+}
+
+// clientTrustingPeriod parses the configured trusting period,
+// shortening it if necessary so that it is less than the unbonding period.
+func clientTrustingPeriod(configured string, unbondingPeriod time.Duration) (time.Duration, error) {
+	tp, err := time.ParseDuration(configured)
+	if err != nil {
+		return 0, fmt.Errorf("invalid trusting period %q: %w", configured, err)
+	}
+	if tp <= 0 || tp >= unbondingPeriod {
+		tp = unbondingPeriod * 2 / 3
+	}
+	return tp, nil
+}
+
+// updateClientMsg builds a MsgUpdateClient that updates clientID on dst to a height of src greater than minHeight.
+// The returned header's height is the height at which proofs from src must be queried.
+func updateClientMsg(ctx context.Context, src, dst *chain, clientID string, minHeight int64) (sdk.Msg, *tmclient.Header, error) {
+	cs, err := dst.clientState(ctx, clientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	trusted, ok := cs.GetLatestHeight().(clienttypes.Height)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected height type %T for client %s", cs.GetLatestHeight(), clientID)
+	}
+
+	if int64(trusted.RevisionHeight) > minHeight {
+		minHeight = int64(trusted.RevisionHeight)
+	}
+	h, err := src.waitForHeightAbove(ctx, minHeight)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hdr, err := src.header(ctx, h)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The trusted validators are the next validators of the trusted header.
+	hdr.TrustedValidators, err = src.validatorSet(ctx, int64(trusted.RevisionHeight)+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	hdr.TrustedHeight = trusted
+
+	signer, err := dst.signer()
+	if err != nil {
+		return nil, nil, err
+	}
+	msg, err := clienttypes.NewMsgUpdateClient(clientID, hdr, signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	return msg, hdr, nil
+}
+
+// updateClient updates clientID on dst to the latest height of src.
+func updateClient(ctx context.Context, src, dst *chain, clientID string) error {
+	msg, _, err := updateClientMsg(ctx, src, dst, clientID, 0)
+	if err != nil {
+		return err
+	}
+	if _, err := dst.sendMsgs(ctx, msg); err != nil {
+		return fmt.Errorf("failed to update client %s on %s: %w", clientID, dst.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// createConnection performs the connection handshake between a and b,
+// where clientA is the client on a tracking b, and clientB is the client on b tracking a.
+// It returns the connection IDs on a and b.
+func createConnection(ctx context.Context, a, b *chain, clientA, clientB string) (string, string, error) {
+	signerA, err := a.signer()
+	if err != nil {
+		return "", "", err
+	}
+	signerB, err := b.signer()
+	if err != nil {
+		return "", "", err
+	}
+
+	// ConnOpenInit on a.
+	res, err := a.sendMsgs(ctx, conntypes.NewMsgConnectionOpenInit(
+		clientA, clientB, ibcPrefix, conntypes.DefaultIBCVersion, 0, signerA,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("connection open init on %s: %w", a.cfg.ChainID, err)
+	}
+	connA, err := eventAttribute(res.TxResult.Events, conntypes.EventTypeConnectionOpenInit, conntypes.AttributeKeyConnectionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// ConnOpenTry on b.
+	update, hdr, err := updateClientMsg(ctx, a, b, clientB, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	proofs, err := connectionProofs(ctx, a, connA, clientA, hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	res, err = b.sendMsgs(ctx, update, conntypes.NewMsgConnectionOpenTry(
+		"", clientB, connA, clientA, proofs.ClientState,
+		ibcPrefix, []*conntypes.Version{conntypes.DefaultIBCVersion}, 0,
+		proofs.Connection, proofs.Client, proofs.Consensus,
+		a.ibcHeight(hdr.Header.Height), proofs.ConsensusHeight, signerB,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("connection open try on %s: %w", b.cfg.ChainID, err)
+	}
+	connB, err := eventAttribute(res.TxResult.Events, conntypes.EventTypeConnectionOpenTry, conntypes.AttributeKeyConnectionID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// ConnOpenAck on a.
+	update, hdr, err = updateClientMsg(ctx, b, a, clientA, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	proofs, err = connectionProofs(ctx, b, connB, clientB, hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	version := conntypes.DefaultIBCVersion
+	if len(proofs.ConnectionEnd.Versions) > 0 {
+		version = proofs.ConnectionEnd.Versions[0]
+	}
+	res, err = a.sendMsgs(ctx, update, conntypes.NewMsgConnectionOpenAck(
+		connA, connB, proofs.ClientState,
+		proofs.Connection, proofs.Client, proofs.Consensus,
+		b.ibcHeight(hdr.Header.Height), proofs.ConsensusHeight,
+		version, signerA,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("connection open ack on %s: %w", a.cfg.ChainID, err)
+	}
+
+	// ConnOpenConfirm on b.
+	update, hdr, err = updateClientMsg(ctx, a, b, clientB, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	_, proofAck, err := a.queryProof(ctx, host.ConnectionKey(connA), hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := b.sendMsgs(ctx, update, conntypes.NewMsgConnectionOpenConfirm(
+		connB, proofAck, a.ibcHeight(hdr.Header.Height), signerB,
+	)); err != nil {
+		return "", "", fmt.Errorf("connection open confirm on %s: %w", b.cfg.ChainID, err)
+	}
+
+	return connA, connB, nil
+}
+
+// connectionHandshakeProofs are the proofs that a connection handshake step requires from the counterparty.
+type connectionHandshakeProofs struct {
+	ConnectionEnd conntypes.ConnectionEnd
+	Connection    []byte
+
+	ClientState *tmclient.ClientState
+	Client      []byte
+
+	ConsensusHeight clienttypes.Height
+	Consensus       []byte
+}
+
+// connectionProofs queries the connection, client state, and consensus state proofs
+// for connID and clientID on c, verifiable at proofHeight.
+func connectionProofs(ctx context.Context, c *chain, connID, clientID string, proofHeight int64) (connectionHandshakeProofs, error) {
+	var p connectionHandshakeProofs
+
+	connBz, connProof, err := c.queryProof(ctx, host.ConnectionKey(connID), proofHeight)
+	if err != nil {
+		return p, err
+	}
+	if err := defaultEncoding.Marshaler.Unmarshal(connBz, &p.ConnectionEnd); err != nil {
+		return p, fmt.Errorf("failed to decode connection %s: %w", connID, err)
+	}
+	p.Connection = connProof
+
+	csBz, clientProof, err := c.queryProof(ctx, host.FullClientStateKey(clientID), proofHeight)
+	if err != nil {
+		return p, err
+	}
+	cs, err := clienttypes.UnmarshalClientState(defaultEncoding.Marshaler, csBz)
+	if err != nil {
+		return p, fmt.Errorf("failed to decode client state %s: %w", clientID, err)
+	}
+	tmcs, ok := cs.(*tmclient.ClientState)
+	if !ok {
+		return p, fmt.Errorf("unexpected client state type %T for client %s", cs, clientID)
+	}
+	p.ClientState = tmcs
+	p.Client = clientProof
+
+	p.ConsensusHeight = tmcs.LatestHeight
+	_, p.Consensus, err = c.queryProof(ctx, host.FullConsensusStateKey(clientID, p.ConsensusHeight), proofHeight)
+	if err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// createChannel performs the channel handshake between a and b over the given connections,
+// returning the channel IDs on a and b.
+func createChannel(ctx context.Context, a, b *chain, clientA, clientB, connA, connB string, opts ibc.CreateChannelOptions) (string, string, error) {
+	signerA, err := a.signer()
+	if err != nil {
+		return "", "", err
+	}
+	signerB, err := b.signer()
+	if err != nil {
+		return "", "", err
+	}
+
+	order := chantypes.UNORDERED
+	if opts.Order == ibc.Ordered {
+		order = chantypes.ORDERED
+	}
+	portA, portB := opts.SourcePortName, opts.DestPortName
+
+	// ChanOpenInit on a.
+	res, err := a.sendMsgs(ctx, chantypes.NewMsgChannelOpenInit(
+		portA, opts.Version, order, []string{connA}, portB, signerA,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("channel open init on %s: %w", a.cfg.ChainID, err)
+	}
+	chanA, err := eventAttribute(res.TxResult.Events, chantypes.EventTypeChannelOpenInit, chantypes.AttributeKeyChannelID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// ChanOpenTry on b.
+	update, hdr, err := updateClientMsg(ctx, a, b, clientB, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	endA, proofInit, err := channelProof(ctx, a, portA, chanA, hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	res, err = b.sendMsgs(ctx, update, chantypes.NewMsgChannelOpenTry(
+		portB, "", opts.Version, order, []string{connB},
+		portA, chanA, endA.Version,
+		proofInit, a.ibcHeight(hdr.Header.Height), signerB,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("channel open try on %s: %w", b.cfg.ChainID, err)
+	}
+	chanB, err := eventAttribute(res.TxResult.Events, chantypes.EventTypeChannelOpenTry, chantypes.AttributeKeyChannelID)
+	if err != nil {
+		return "", "", err
+	}
+
+	// ChanOpenAck on a.
+	update, hdr, err = updateClientMsg(ctx, b, a, clientA, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	endB, proofTry, err := channelProof(ctx, b, portB, chanB, hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	res, err = a.sendMsgs(ctx, update, chantypes.NewMsgChannelOpenAck(
+		portA, chanA, chanB, endB.Version,
+		proofTry, b.ibcHeight(hdr.Header.Height), signerA,
+	))
+	if err != nil {
+		return "", "", fmt.Errorf("channel open ack on %s: %w", a.cfg.ChainID, err)
+	}
+
+	// ChanOpenConfirm on b.
+	update, hdr, err = updateClientMsg(ctx, a, b, clientB, res.Height)
+	if err != nil {
+		return "", "", err
+	}
+	_, proofAck, err := channelProof(ctx, a, portA, chanA, hdr.Header.Height)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := b.sendMsgs(ctx, update, chantypes.NewMsgChannelOpenConfirm(
+		portB, chanB, proofAck, a.ibcHeight(hdr.Header.Height), signerB,
+	)); err != nil {
+		return "", "", fmt.Errorf("channel open confirm on %s: %w", b.cfg.ChainID, err)
+	}
+
+	return chanA, chanB, nil
+}
+
+// closeChannel closes the channel portA/chanA on a, if it is still open,
+// and then closes its counterparty on b.
+func closeChannel(ctx context.Context, a, b *chain, clientB, portA, chanA string) error {
+	res, err := chantypes.NewQueryClient(a.grpc).Channel(ctx, &chantypes.QueryChannelRequest{
+		PortId:    portA,
+		ChannelId: chanA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query channel %s/%s on %s: %w", portA, chanA, a.cfg.ChainID, err)
+	}
+	portB, chanB := res.Channel.Counterparty.PortId, res.Channel.Counterparty.ChannelId
+
+	minHeight, _, err := a.status(ctx)
+	if err != nil {
+		return err
+	}
+
+	// ChanCloseInit on a, unless a's end was already closed, e.g. by a timeout on an ordered channel.
+	if res.Channel.State != chantypes.CLOSED {
+		signerA, err := a.signer()
+		if err != nil {
+			return err
+		}
+		initRes, err := a.sendMsgs(ctx, chantypes.NewMsgChannelCloseInit(portA, chanA, signerA))
+		if err != nil {
+			return fmt.Errorf("channel close init on %s: %w", a.cfg.ChainID, err)
+		}
+		minHeight = initRes.Height
+	}
+
+	// ChanCloseConfirm on b.
+	signerB, err := b.signer()
+	if err != nil {
+		return err
+	}
+	update, hdr, err := updateClientMsg(ctx, a, b, clientB, minHeight)
+	if err != nil {
+		return err
+	}
+	_, proofInit, err := channelProof(ctx, a, portA, chanA, hdr.Header.Height)
+	if err != nil {
+		return err
+	}
+	if _, err := b.sendMsgs(ctx, update, chantypes.NewMsgChannelCloseConfirm(
+		portB, chanB, proofInit, a.ibcHeight(hdr.Header.Height), signerB,
+	)); err != nil {
+		return fmt.Errorf("channel close confirm on %s: %w", b.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// channelProof returns the channel end of portID/channelID on c and its proof at proofHeight.
+func channelProof(ctx context.Context, c *chain, portID, channelID string, proofHeight int64) (chantypes.Channel, []byte, error) {
+	var ch chantypes.Channel
+	bz, proof, err := c.queryProof(ctx, host.ChannelKey(portID, channelID), proofHeight)
+	if err != nil {
+		return ch, nil, err
+	}
+	if err := defaultEncoding.Marshaler.Unmarshal(bz, &ch); err != nil {
+		return ch, nil, fmt.Errorf("failed to decode channel %s/%s: %w", portID, channelID, err)
+	}
+	return ch, proof, nil
+}
@@ -0,0 +1,302 @@
+package inprocess
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+)
+
+// channelEnd is one end of a channel, together with the client
+// on the same chain that tracks the counterparty chain.
+type channelEnd struct {
+	Chain     *chain
+	ClientID  string
+	PortID    string
+	ChannelID string
+}
+
+// relayPackets delivers packets committed on src to dst,
+// or times them out on src if they can no longer be received on dst.
+func relayPackets(ctx context.Context, src, dst channelEnd, ordered bool) error {
+	observedHeight, _, err := src.Chain.status(ctx)
+	if err != nil {
+		return err
+	}
+
+	comms, err := chantypes.NewQueryClient(src.Chain.grpc).PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+		PortId:     src.PortID,
+		ChannelId:  src.ChannelID,
+		Pagination: allPages,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query packet commitments on %s: %w", src.Chain.cfg.ChainID, err)
+	}
+	if len(comms.Commitments) == 0 {
+		return nil
+	}
+
+	seqs := make([]uint64, len(comms.Commitments))
+	for i, c := range comms.Commitments {
+		seqs[i] = c.Sequence
+	}
+	unreceived, err := chantypes.NewQueryClient(dst.Chain.grpc).UnreceivedPackets(ctx, &chantypes.QueryUnreceivedPacketsRequest{
+		PortId:                    dst.PortID,
+		ChannelId:                 dst.ChannelID,
+		PacketCommitmentSequences: seqs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query unreceived packets on %s: %w", dst.Chain.cfg.ChainID, err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return nil
+	}
+
+	dstHeight, dstTime, err := dst.Chain.status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var recv, timeout []chantypes.Packet
+	for _, seq := range unreceived.Sequences {
+		packet, err := sentPacket(ctx, src, seq)
+		if err != nil {
+			return err
+		}
+
+		// A packet can no longer be received once dst reaches its timeout,
+		// and the earliest block that could include a MsgRecvPacket is the next one.
+		if packetTimedOut(packet, dst.Chain.ibcHeight(dstHeight+1), dstTime) {
+			timeout = append(timeout, packet)
+		} else {
+			recv = append(recv, packet)
+		}
+	}
+
+	if len(recv) > 0 {
+		if err := recvPackets(ctx, src, dst, recv, observedHeight); err != nil {
+			return err
+		}
+	}
+	if len(timeout) > 0 {
+		if err := timeoutPackets(ctx, src, dst, timeout, ordered, dstHeight); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// packetTimedOut reports whether packet has timed out on a chain at the given height and time.
+func packetTimedOut(packet chantypes.Packet, height clienttypes.Height, t time.Time) bool {
+	if !packet.TimeoutHeight.IsZero() && height.GTE(packet.TimeoutHeight) {
+		return true
+	}
+	return packet.TimeoutTimestamp != 0 && uint64(t.UnixNano()) >= packet.TimeoutTimestamp
+}
+
+func recvPackets(ctx context.Context, src, dst channelEnd, packets []chantypes.Packet, minHeight int64) error {
+	update, hdr, err := updateClientMsg(ctx, src.Chain, dst.Chain, dst.ClientID, minHeight)
+	if err != nil {
+		return err
+	}
+	proofHeight := src.Chain.ibcHeight(hdr.Header.Height)
+
+	signer, err := dst.Chain.signer()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{update}
+	for _, packet := range packets {
+		_, proof, err := src.Chain.queryProof(ctx, host.PacketCommitmentKey(src.PortID, src.ChannelID, packet.Sequence), hdr.Header.Height)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, chantypes.NewMsgRecvPacket(packet, proof, proofHeight, signer))
+	}
+
+	if _, err := dst.Chain.sendMsgs(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to relay %d packet(s) to %s: %w", len(packets), dst.Chain.cfg.ChainID, err)
+	}
+	return nil
+}
+
+func timeoutPackets(ctx context.Context, src, dst channelEnd, packets []chantypes.Packet, ordered bool, minHeight int64) error {
+	update, hdr, err := updateClientMsg(ctx, dst.Chain, src.Chain, src.ClientID, minHeight)
+	if err != nil {
+		return err
+	}
+	proofHeight := dst.Chain.ibcHeight(hdr.Header.Height)
+
+	signer, err := src.Chain.signer()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{update}
+	for _, packet := range packets {
+		var (
+			nextSeqRecv = packet.Sequence
+			proof       []byte
+		)
+		if ordered {
+			var bz []byte
+			bz, proof, err = dst.Chain.queryProof(ctx, host.NextSequenceRecvKey(dst.PortID, dst.ChannelID), hdr.Header.Height)
+			if err == nil {
+				nextSeqRecv = sdk.BigEndianToUint64(bz)
+			}
+		} else {
+			_, proof, err = dst.Chain.queryProof(ctx, host.PacketReceiptKey(dst.PortID, dst.ChannelID, packet.Sequence), hdr.Header.Height)
+		}
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, chantypes.NewMsgTimeout(packet, nextSeqRecv, proof, proofHeight, signer))
+	}
+
+	if _, err := src.Chain.sendMsgs(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to time out %d packet(s) on %s: %w", len(packets), src.Chain.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// relayAcknowledgements delivers acknowledgements written on dst, for packets sent from src, back to src.
+func relayAcknowledgements(ctx context.Context, src, dst channelEnd) error {
+	observedHeight, _, err := dst.Chain.status(ctx)
+	if err != nil {
+		return err
+	}
+
+	acks, err := chantypes.NewQueryClient(dst.Chain.grpc).PacketAcknowledgements(ctx, &chantypes.QueryPacketAcknowledgementsRequest{
+		PortId:     dst.PortID,
+		ChannelId:  dst.ChannelID,
+		Pagination: allPages,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query packet acknowledgements on %s: %w", dst.Chain.cfg.ChainID, err)
+	}
+	if len(acks.Acknowledgements) == 0 {
+		return nil
+	}
+
+	seqs := make([]uint64, len(acks.Acknowledgements))
+	for i, a := range acks.Acknowledgements {
+		seqs[i] = a.Sequence
+	}
+	unreceived, err := chantypes.NewQueryClient(src.Chain.grpc).UnreceivedAcks(ctx, &chantypes.QueryUnreceivedAcksRequest{
+		PortId:             src.PortID,
+		ChannelId:          src.ChannelID,
+		PacketAckSequences: seqs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query unreceived acknowledgements on %s: %w", src.Chain.cfg.ChainID, err)
+	}
+	if len(unreceived.Sequences) == 0 {
+		return nil
+	}
+
+	update, hdr, err := updateClientMsg(ctx, dst.Chain, src.Chain, src.ClientID, observedHeight)
+	if err != nil {
+		return err
+	}
+	proofHeight := dst.Chain.ibcHeight(hdr.Header.Height)
+
+	signer, err := src.Chain.signer()
+	if err != nil {
+		return err
+	}
+
+	msgs := []sdk.Msg{update}
+	for _, seq := range unreceived.Sequences {
+		packet, ack, err := writtenAcknowledgement(ctx, dst, seq)
+		if err != nil {
+			return err
+		}
+		_, proof, err := dst.Chain.queryProof(ctx, host.PacketAcknowledgementKey(dst.PortID, dst.ChannelID, seq), hdr.Header.Height)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, chantypes.NewMsgAcknowledgement(packet, ack, proof, proofHeight, signer))
+	}
+
+	if _, err := src.Chain.sendMsgs(ctx, msgs...); err != nil {
+		return fmt.Errorf("failed to relay %d acknowledgement(s) to %s: %w", len(unreceived.Sequences), src.Chain.cfg.ChainID, err)
+	}
+	return nil
+}
+
+// sentPacket finds the packet with sequence seq that was sent from the given channel end.
+func sentPacket(ctx context.Context, src channelEnd, seq uint64) (chantypes.Packet, error) {
+	events, err := src.Chain.searchEvents(ctx, chantypes.EventTypeSendPacket, map[string]string{
+		chantypes.AttributeKeySrcPort:    src.PortID,
+		chantypes.AttributeKeySrcChannel: src.ChannelID,
+		chantypes.AttributeKeySequence:   strconv.FormatUint(seq, 10),
+	})
+	if err != nil {
+		return chantypes.Packet{}, err
+	}
+	if len(events) == 0 {
+		return chantypes.Packet{}, fmt.Errorf("no send_packet event found for %s/%s sequence %d", src.PortID, src.ChannelID, seq)
+	}
+	return packetFromEvent(events[0])
+}
+
+// writtenAcknowledgement finds the packet with sequence seq that was received on the given channel end,
+// along with the acknowledgement written for it.
+func writtenAcknowledgement(ctx context.Context, dst channelEnd, seq uint64) (chantypes.Packet, []byte, error) {
+	events, err := dst.Chain.searchEvents(ctx, chantypes.EventTypeWriteAck, map[string]string{
+		chantypes.AttributeKeyDstPort:    dst.PortID,
+		chantypes.AttributeKeyDstChannel: dst.ChannelID,
+		chantypes.AttributeKeySequence:   strconv.FormatUint(seq, 10),
+	})
+	if err != nil {
+		return chantypes.Packet{}, nil, err
+	}
+	if len(events) == 0 {
+		return chantypes.Packet{}, nil, fmt.Errorf("no write_acknowledgement event found for %s/%s sequence %d", dst.PortID, dst.ChannelID, seq)
+	}
+
+	packet, err := packetFromEvent(events[0])
+	if err != nil {
+		return chantypes.Packet{}, nil, err
+	}
+	ack, err := decodeEventBytes(events[0], chantypes.AttributeKeyAck)
+	if err != nil {
+		return chantypes.Packet{}, nil, err
+	}
+	return packet, ack, nil
+}
+
+// packetFromEvent rebuilds a packet from the attributes of a packet event.
+func packetFromEvent(attrs map[string]string) (chantypes.Packet, error) {
+	var p chantypes.Packet
+
+	data, err := decodeEventBytes(attrs, chantypes.AttributeKeyData)
+	if err != nil {
+		return p, err
+	}
+	seq, err := strconv.ParseUint(attrs[chantypes.AttributeKeySequence], 10, 64)
+	if err != nil {
+		return p, fmt.Errorf("invalid packet sequence: %w", err)
+	}
+	timeoutHeight, err := clienttypes.ParseHeight(attrs[chantypes.AttributeKeyTimeoutHeight])
+	if err != nil {
+		return p, fmt.Errorf("invalid packet timeout height: %w", err)
+	}
+	timeoutTimestamp, err := strconv.ParseUint(attrs[chantypes.AttributeKeyTimeoutTimestamp], 10, 64)
+	if err != nil {
+		return p, fmt.Errorf("invalid packet timeout timestamp: %w", err)
+	}
+
+	return chantypes.NewPacket(
+		data, seq,
+		attrs[chantypes.AttributeKeySrcPort], attrs[chantypes.AttributeKeySrcChannel],
+		attrs[chantypes.AttributeKeyDstPort], attrs[chantypes.AttributeKeyDstChannel],
+		timeoutHeight, timeoutTimestamp,
+	), nil
+}
@@ -0,0 +1,65 @@
+package inprocess
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPacketFromEvent(t *testing.T) {
+	attrs := map[string]string{
+		chantypes.AttributeKeyData:             "ignored in favor of hex",
+		chantypes.AttributeKeyDataHex:          hex.EncodeToString([]byte(`{"amount":"1"}`)),
+		chantypes.AttributeKeySequence:         "7",
+		chantypes.AttributeKeyTimeoutHeight:    "1-100",
+		chantypes.AttributeKeyTimeoutTimestamp: "0",
+		chantypes.AttributeKeySrcPort:          "transfer",
+		chantypes.AttributeKeySrcChannel:       "channel-0",
+		chantypes.AttributeKeyDstPort:          "transfer",
+		chantypes.AttributeKeyDstChannel:       "channel-1",
+	}
+
+	p, err := packetFromEvent(attrs)
+	require.NoError(t, err)
+	require.Equal(t, []byte(`{"amount":"1"}`), p.Data)
+	require.Equal(t, uint64(7), p.Sequence)
+	require.Equal(t, clienttypes.NewHeight(1, 100), p.TimeoutHeight)
+	require.Equal(t, "channel-0", p.SourceChannel)
+	require.Equal(t, "channel-1", p.DestinationChannel)
+
+	delete(attrs, chantypes.AttributeKeySequence)
+	_, err = packetFromEvent(attrs)
+	require.Error(t, err)
+}
+
+func TestPacketTimedOut(t *testing.T) {
+	now := time.Unix(1_000, 0)
+
+	heightPacket := chantypes.Packet{TimeoutHeight: clienttypes.NewHeight(1, 100)}
+	require.False(t, packetTimedOut(heightPacket, clienttypes.NewHeight(1, 99), now))
+	require.True(t, packetTimedOut(heightPacket, clienttypes.NewHeight(1, 100), now))
+
+	timestampPacket := chantypes.Packet{TimeoutTimestamp: uint64(now.UnixNano())}
+	require.False(t, packetTimedOut(timestampPacket, clienttypes.NewHeight(1, 1), now.Add(-time.Second)))
+	require.True(t, packetTimedOut(timestampPacket, clienttypes.NewHeight(1, 1), now))
+}
+
+func TestClientTrustingPeriod(t *testing.T) {
+	const unbonding = 3 * time.Hour
+
+	tp, err := clientTrustingPeriod("1h", unbonding)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, tp)
+
+	// Trusting periods that are not less than the unbonding period are shortened.
+	tp, err = clientTrustingPeriod("504h", unbonding)
+	require.NoError(t, err)
+	require.Equal(t, 2*time.Hour, tp)
+
+	_, err = clientTrustingPeriod("forever", unbonding)
+	require.Error(t, err)
+}
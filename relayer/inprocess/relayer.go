@@ -0,0 +1,742 @@
+// Package inprocess provides an ibc.Relayer implementation that runs inside the test process.
+//
+// The relayer connects to the chains' host-exposed RPC and gRPC ports,
+// so it can be stepped through with a debugger
+// and does not depend on any relayer container image.
+package inprocess
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	tmclient "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/relayer"
+	"go.uber.org/zap"
+)
+
+// RelayInterval is how often a started relayer checks for packets and acknowledgements to relay.
+const RelayInterval = time.Second
+
+// Capabilities returns the set of capabilities of the in-process relayer.
+//
+// The in-process relayer only relays packets on channels it created itself,
+// and it only closes channels through CloseChannel;
+// it does not watch for handshakes or misbehaviour initiated elsewhere.
+func Capabilities() map[relayer.Capability]bool {
+	m := relayer.FullCapabilities()
+	m[relayer.OrderedChannels] = false
+	m[relayer.Misbehaviour] = false
+	m[relayer.ConnectionDelay] = false
+	return m
+}
+
+// InProcessRelayer is an ibc.Relayer that performs the IBC handshakes
+// and relays packets using Go clients against the chains under test.
+type InProcessRelayer struct {
+	log *zap.Logger
+
+	mu      sync.Mutex
+	chains  map[string]*chain // Keyed by chain ID.
+	wallets map[string]ibc.RelayerWallet
+	paths   map[string]*path
+
+	// How often the relayer relays in the background; RelayInterval unless changed by tests.
+	interval time.Duration
+
+	// Set while relaying in the background, between StartRelayer and StopRelayer.
+	// The paths are retained while the relayer is paused, so that it can be resumed.
+	cancel    context.CancelFunc
+	done      chan struct{}
+	pathNames []string
+}
+
+// path records the chains and the IBC objects that the relayer created between them.
+type path struct {
+	Src, Dst string // Chain IDs.
+
+	// Client on Src tracking Dst, and client on Dst tracking Src.
+	SrcClientID, DstClientID string
+
+	SrcConnectionID, DstConnectionID string
+
+	// Restricts the channels relayed by StartRelayer.
+	PacketFilter ibc.PacketFilter
+}
+
+// NewInProcessRelayer returns a new in-process relayer.
+func NewInProcessRelayer(log *zap.Logger) *InProcessRelayer {
+	return &InProcessRelayer{
+		log: log,
+
+		chains:  make(map[string]*chain),
+		wallets: make(map[string]ibc.RelayerWallet),
+		paths:   make(map[string]*path),
+
+		interval: RelayInterval,
+	}
+}
+
+var (
+	_ ibc.FaultInjectableRelayer = (*InProcessRelayer)(nil)
+	_ ibc.PathSharingRelayer     = (*InProcessRelayer)(nil)
+)
+
+// UseDockerNetwork reports false, as the relayer connects to the host-exposed ports of the chains.
+func (r *InProcessRelayer) UseDockerNetwork() bool {
+	return false
+}
+
+// track reports an operation of the relayer to rep.
+// There is no container or process, so the command is a description of the operation.
+func (r *InProcessRelayer) track(rep ibc.RelayerExecReporter, startedAt time.Time, stdout string, err error, command ...string) {
+	relayer.TrackOperation(rep, "inprocess", startedAt, stdout, err, command...)
+}
+
+func (r *InProcessRelayer) chain(chainID string) (*chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c, ok := r.chains[chainID]
+	if !ok {
+		return nil, fmt.Errorf("chain %s has not been configured", chainID)
+	}
+	return c, nil
+}
+
+// path returns a copy of the named path along with its chains.
+func (r *InProcessRelayer) path(pathName string) (path, *chain, *chain, error) {
+	r.mu.Lock()
+	p, ok := r.paths[pathName]
+	var cp path
+	if ok {
+		cp = *p
+	}
+	r.mu.Unlock()
+	if !ok {
+		return path{}, nil, nil, fmt.Errorf("path %s has not been generated", pathName)
+	}
+
+	src, err := r.chain(cp.Src)
+	if err != nil {
+		return path{}, nil, nil, err
+	}
+	dst, err := r.chain(cp.Dst)
+	if err != nil {
+		return path{}, nil, nil, err
+	}
+	return cp, src, dst, nil
+}
+
+func (r *InProcessRelayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "add-chain", chainConfig.ChainID, rpcAddr, grpcAddr) }()
+
+	c, err := newChain(r.log, chainConfig, keyName, rpcAddr, grpcAddr)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.chains[chainConfig.ChainID]; exists {
+		return fmt.Errorf("chain %s is already configured", chainConfig.ChainID)
+	}
+	r.chains[chainConfig.ChainID] = c
+	return nil
+}
+
+func (r *InProcessRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, mnemonic string) (err error) {
+	startedAt := time.Now()
+	var addr string
+	defer func() { r.track(rep, startedAt, addr, err, "restore-key", chainID, keyName) }()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return err
+	}
+	addr, err = c.restoreKey(keyName, mnemonic)
+	if err != nil {
+		return fmt.Errorf("failed to restore key %s for chain %s: %w", keyName, chainID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wallets[chainID] = ibc.RelayerWallet{Mnemonic: mnemonic, Address: addr}
+	return nil
+}
+
+func (r *InProcessRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName string) (wallet ibc.RelayerWallet, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, wallet.Address, err, "add-key", chainID, keyName) }()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return wallet, err
+	}
+	wallet, err = c.addKey(keyName)
+	if err != nil {
+		return wallet, fmt.Errorf("failed to add key %s for chain %s: %w", keyName, chainID, err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.wallets[chainID] = wallet
+	return wallet, nil
+}
+
+func (r *InProcessRelayer) GetWallet(chainID string) (ibc.RelayerWallet, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	w, ok := r.wallets[chainID]
+	return w, ok
+}
+
+func (r *InProcessRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.PathOptions) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "generate-path", srcChainID, dstChainID, pathName) }()
+
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid path options: %w", err)
+	}
+	if opts.ConnectionDelay > 0 {
+		return fmt.Errorf("in-process relayer does not support connection delays")
+	}
+
+	if _, err := r.chain(srcChainID); err != nil {
+		return err
+	}
+	if _, err := r.chain(dstChainID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[pathName] = &path{Src: srcChainID, Dst: dstChainID, PacketFilter: opts.PacketFilter}
+	return nil
+}
+
+func (r *InProcessRelayer) GenerateSharedPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) (err error) {
+	startedAt := time.Now()
+	defer func() {
+		r.track(rep, startedAt, "", err, "generate-shared-path", src.ChainID, dst.ChainID, pathName, src.ConnectionID, dst.ConnectionID)
+	}()
+
+	if _, err := r.chain(src.ChainID); err != nil {
+		return err
+	}
+	if _, err := r.chain(dst.ChainID); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.paths[pathName] = &path{
+		Src: src.ChainID, Dst: dst.ChainID,
+
+		SrcClientID: src.ClientID, DstClientID: dst.ClientID,
+
+		SrcConnectionID: src.ConnectionID, DstConnectionID: dst.ConnectionID,
+	}
+	return nil
+}
+
+func (r *InProcessRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	if err := r.CreateClients(ctx, rep, pathName); err != nil {
+		return err
+	}
+	if err := r.CreateConnections(ctx, rep, pathName); err != nil {
+		return err
+	}
+	return r.CreateChannel(ctx, rep, pathName, opts)
+}
+
+func (r *InProcessRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	var out string
+	defer func() { r.track(rep, startedAt, out, err, "create-clients", pathName) }()
+
+	_, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+
+	srcClientID, err := createClient(ctx, dst, src)
+	if err != nil {
+		return err
+	}
+	dstClientID, err := createClient(ctx, src, dst)
+	if err != nil {
+		return err
+	}
+	out = fmt.Sprintf("%s: %s\n%s: %s\n", src.cfg.ChainID, srcClientID, dst.cfg.ChainID, dstClientID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p := r.paths[pathName]
+	p.SrcClientID, p.DstClientID = srcClientID, dstClientID
+	return nil
+}
+
+func (r *InProcessRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	var out string
+	defer func() { r.track(rep, startedAt, out, err, "create-connections", pathName) }()
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcClientID == "" || p.DstClientID == "" {
+		return fmt.Errorf("path %s has no clients; call CreateClients first", pathName)
+	}
+
+	srcConnID, dstConnID, err := createConnection(ctx, src, dst, p.SrcClientID, p.DstClientID)
+	if err != nil {
+		return err
+	}
+	out = fmt.Sprintf("%s: %s\n%s: %s\n", src.cfg.ChainID, srcConnID, dst.cfg.ChainID, dstConnID)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rp := r.paths[pathName]
+	rp.SrcConnectionID, rp.DstConnectionID = srcConnID, dstConnID
+	return nil
+}
+
+func (r *InProcessRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) (err error) {
+	startedAt := time.Now()
+	var out string
+	defer func() {
+		r.track(rep, startedAt, out, err, "create-channel", pathName, opts.SourcePortName, opts.DestPortName)
+	}()
+
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcConnectionID == "" || p.DstConnectionID == "" {
+		return fmt.Errorf("path %s has no connection; call CreateConnections first", pathName)
+	}
+
+	srcChanID, dstChanID, err := createChannel(ctx, src, dst, p.SrcClientID, p.DstClientID, p.SrcConnectionID, p.DstConnectionID, opts)
+	if err != nil {
+		return err
+	}
+	out = fmt.Sprintf("%s: %s\n%s: %s\n", src.cfg.ChainID, srcChanID, dst.cfg.ChainID, dstChanID)
+	return nil
+}
+
+func (r *InProcessRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "close-channel", pathName, channelID) }()
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcConnectionID == "" {
+		return fmt.Errorf("path %s has no connection; call LinkPath first", pathName)
+	}
+
+	res, err := chantypes.NewQueryClient(src.grpc).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{
+		Connection: p.SrcConnectionID,
+		Pagination: allPages,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query channels of %s on %s: %w", p.SrcConnectionID, src.cfg.ChainID, err)
+	}
+	for _, ch := range res.Channels {
+		if ch.ChannelId == channelID {
+			return closeChannel(ctx, src, dst, p.DstClientID, ch.PortId, ch.ChannelId)
+		}
+	}
+	return fmt.Errorf("channel %s not found on path %s", channelID, pathName)
+}
+
+func (r *InProcessRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "update-clients", pathName) }()
+
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return err
+	}
+	if p.SrcClientID == "" || p.DstClientID == "" {
+		return fmt.Errorf("path %s has no clients; call CreateClients first", pathName)
+	}
+
+	if err := updateClient(ctx, dst, src, p.SrcClientID); err != nil {
+		return err
+	}
+	return updateClient(ctx, src, dst, p.DstClientID)
+}
+
+func (r *InProcessRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (channels []ibc.ChannelOutput, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-channels", chainID) }()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := chantypes.NewQueryClient(c.grpc).Channels(ctx, &chantypes.QueryChannelsRequest{Pagination: allPages})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels on %s: %w", chainID, err)
+	}
+
+	channels = make([]ibc.ChannelOutput, len(res.Channels))
+	for i, ch := range res.Channels {
+		channels[i] = ibc.ChannelOutput{
+			State:    ch.State.String(),
+			Ordering: ch.Ordering.String(),
+			Counterparty: ibc.ChannelCounterparty{
+				PortID:    ch.Counterparty.PortId,
+				ChannelID: ch.Counterparty.ChannelId,
+			},
+			ConnectionHops: ch.ConnectionHops,
+			Version:        ch.Version,
+			PortID:         ch.PortId,
+			ChannelID:      ch.ChannelId,
+		}
+	}
+	return channels, nil
+}
+
+func (r *InProcessRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (connections ibc.ConnectionOutputs, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-connections", chainID) }()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conntypes.NewQueryClient(c.grpc).Connections(ctx, &conntypes.QueryConnectionsRequest{Pagination: allPages})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections on %s: %w", chainID, err)
+	}
+
+	connections = make(ibc.ConnectionOutputs, len(res.Connections))
+	for i, conn := range res.Connections {
+		counterparty := conn.Counterparty
+		connections[i] = &ibc.ConnectionOutput{
+			ID:           conn.Id,
+			ClientID:     conn.ClientId,
+			Versions:     conn.Versions,
+			State:        conn.State.String(),
+			Counterparty: &counterparty,
+			DelayPeriod:  fmt.Sprint(conn.DelayPeriod),
+		}
+	}
+	return connections, nil
+}
+
+func (r *InProcessRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (clients []ibc.ClientOutput, err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "get-clients", chainID) }()
+
+	c, err := r.chain(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := clienttypes.NewQueryClient(c.grpc).ClientStates(ctx, &clienttypes.QueryClientStatesRequest{Pagination: allPages})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query client states on %s: %w", chainID, err)
+	}
+
+	clients = make([]ibc.ClientOutput, 0, len(res.ClientStates))
+	for _, identified := range res.ClientStates {
+		cs, err := clienttypes.UnpackClientState(identified.ClientState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack client state for %s: %w", identified.ClientId, err)
+		}
+		tmState, ok := cs.(*tmclient.ClientState)
+		if !ok {
+			// Only tendermint clients are created by the relayer.
+			continue
+		}
+		clients = append(clients, ibc.ClientOutput{
+			ID:             identified.ClientId,
+			ChainID:        tmState.ChainId,
+			TrustingPeriod: tmState.TrustingPeriod,
+			LatestHeight:   tmState.LatestHeight,
+			Frozen:         !tmState.FrozenHeight.IsZero(),
+		})
+	}
+	return clients, nil
+}
+
+// channelEnds returns both ends of every open channel on the path's connection.
+// If channelID is not empty, only the channel with that ID on the path's source chain is returned;
+// otherwise, channels excluded by the path's packet filter are omitted.
+func (r *InProcessRelayer) channelEnds(ctx context.Context, pathName, channelID string) ([]channelEndPair, error) {
+	p, src, dst, err := r.path(pathName)
+	if err != nil {
+		return nil, err
+	}
+	if p.SrcConnectionID == "" {
+		return nil, fmt.Errorf("path %s has no connection; call LinkPath first", pathName)
+	}
+
+	res, err := chantypes.NewQueryClient(src.grpc).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{
+		Connection: p.SrcConnectionID,
+		Pagination: allPages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query channels of %s on %s: %w", p.SrcConnectionID, src.cfg.ChainID, err)
+	}
+
+	var pairs []channelEndPair
+	for _, ch := range res.Channels {
+		if ch.State != chantypes.OPEN || (channelID != "" && ch.ChannelId != channelID) {
+			continue
+		}
+		if channelID == "" && !p.PacketFilter.Allows(ch.ChannelId) {
+			continue
+		}
+		pairs = append(pairs, channelEndPair{
+			Src: channelEnd{Chain: src, ClientID: p.SrcClientID, PortID: ch.PortId, ChannelID: ch.ChannelId},
+			Dst: channelEnd{Chain: dst, ClientID: p.DstClientID, PortID: ch.Counterparty.PortId, ChannelID: ch.Counterparty.ChannelId},
+
+			Ordered: ch.Ordering == chantypes.ORDERED,
+		})
+	}
+	if channelID != "" && len(pairs) == 0 {
+		return nil, fmt.Errorf("no open channel %s found on path %s", channelID, pathName)
+	}
+	return pairs, nil
+}
+
+// channelEndPair is both ends of a channel on a path.
+type channelEndPair struct {
+	Src, Dst channelEnd
+	Ordered  bool
+}
+
+// relay relays packets and/or acknowledgements in both directions across the given channels.
+func relay(ctx context.Context, pairs []channelEndPair, packets, acks bool) error {
+	var errs []error
+	for _, pair := range pairs {
+		if packets {
+			errs = append(errs,
+				relayPackets(ctx, pair.Src, pair.Dst, pair.Ordered),
+				relayPackets(ctx, pair.Dst, pair.Src, pair.Ordered),
+			)
+		}
+		if acks {
+			errs = append(errs,
+				relayAcknowledgements(ctx, pair.Src, pair.Dst),
+				relayAcknowledgements(ctx, pair.Dst, pair.Src),
+			)
+		}
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *InProcessRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "flush-packets", pathName, channelID) }()
+
+	pairs, err := r.channelEnds(ctx, pathName, channelID)
+	if err != nil {
+		return err
+	}
+	return relay(ctx, pairs, true, false)
+}
+
+func (r *InProcessRelayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "flush-acknowledgements", pathName, channelID) }()
+
+	pairs, err := r.channelEnds(ctx, pathName, channelID)
+	if err != nil {
+		return err
+	}
+	return relay(ctx, pairs, false, true)
+}
+
+func (r *InProcessRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, append([]string{"start"}, pathNames...)...) }()
+
+	if len(pathNames) == 0 {
+		return errors.New("no paths to relay")
+	}
+	for _, pathName := range pathNames {
+		if _, _, _, err := r.path(pathName); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pathNames != nil {
+		return errors.New("relayer is already started")
+	}
+
+	r.pathNames = pathNames
+	r.startLoop()
+
+	return nil
+}
+
+// startLoop starts relaying on r.pathNames in the background.
+// The caller must hold r.mu.
+func (r *InProcessRelayer) startLoop() {
+	// The relayer outlives the context passed to StartRelayer,
+	// and runs until StopRelayer is called.
+	runCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.done = make(chan struct{})
+
+	go r.run(runCtx, r.pathNames, r.done)
+}
+
+// detachLoop stops relaying in the background, if the relayer is not paused,
+// and returns a function that waits for the in-flight relay attempt to be abandoned.
+// The caller must hold r.mu, and must release it before waiting,
+// as the in-flight relay attempt may itself need r.mu to finish.
+func (r *InProcessRelayer) detachLoop() (wait func(ctx context.Context) error) {
+	cancel, done := r.cancel, r.done
+	r.cancel, r.done = nil, nil
+	if cancel == nil {
+		return func(context.Context) error { return nil }
+	}
+
+	cancel()
+	return func(ctx context.Context) error {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// run relays packets and acknowledgements on the paths every r.interval, until ctx is canceled.
+func (r *InProcessRelayer) run(ctx context.Context, pathNames []string, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, pathName := range pathNames {
+			pairs, err := r.channelEnds(ctx, pathName, "")
+			if err == nil {
+				err = relay(ctx, pairs, true, true)
+			}
+			if err != nil && ctx.Err() == nil {
+				r.log.Info("Failed to relay", zap.String("path", pathName), zap.Error(err))
+			}
+		}
+	}
+}
+
+func (r *InProcessRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "stop") }()
+
+	r.mu.Lock()
+	if r.pathNames == nil {
+		r.mu.Unlock()
+		return errors.New("relayer is not started")
+	}
+	r.pathNames = nil
+	wait := r.detachLoop()
+	r.mu.Unlock()
+
+	return wait(ctx)
+}
+
+func (r *InProcessRelayer) PauseRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "pause") }()
+
+	r.mu.Lock()
+	if r.pathNames == nil {
+		r.mu.Unlock()
+		return errors.New("relayer is not started")
+	}
+	if r.cancel == nil {
+		r.mu.Unlock()
+		return errors.New("relayer is already paused")
+	}
+	wait := r.detachLoop()
+	r.mu.Unlock()
+
+	return wait(ctx)
+}
+
+func (r *InProcessRelayer) ResumeRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "resume") }()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pathNames == nil {
+		return errors.New("relayer is not started")
+	}
+	if r.cancel != nil {
+		return errors.New("relayer is not paused")
+	}
+
+	r.startLoop()
+	return nil
+}
+
+func (r *InProcessRelayer) RestartRelayer(ctx context.Context, rep ibc.RelayerExecReporter) (err error) {
+	startedAt := time.Now()
+	defer func() { r.track(rep, startedAt, "", err, "restart") }()
+
+	r.mu.Lock()
+	if r.pathNames == nil {
+		r.mu.Unlock()
+		return errors.New("relayer is not started")
+	}
+	wait := r.detachLoop()
+	r.mu.Unlock()
+
+	// Abandon the in-flight relay attempt before starting again,
+	// as a restarted relayer has none of the state of the one it replaces.
+	if err := wait(ctx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pathNames == nil {
+		return errors.New("relayer was stopped while restarting")
+	}
+	if r.cancel == nil {
+		r.startLoop()
+	}
+	return nil
+}
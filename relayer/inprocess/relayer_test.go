@@ -0,0 +1,46 @@
+package inprocess
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestInProcessRelayer_StopWhileRelaying(t *testing.T) {
+	t.Parallel()
+
+	r := NewInProcessRelayer(zaptest.NewLogger(t))
+	r.interval = time.Millisecond
+
+	// The path's chains are not configured, so every relay attempt fails,
+	// but only after locking the relayer to look up the path and its chains.
+	r.paths["p"] = &path{Src: "c0", Dst: "c1"}
+	start := func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.pathNames = []string{"p"}
+		r.startLoop()
+	}
+
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	start()
+	for i := 0; i < 50; i++ {
+		time.Sleep(time.Duration(i%3) * time.Millisecond)
+		require.NoError(t, r.RestartRelayer(ctx, rep))
+
+		require.NoError(t, r.PauseRelayer(ctx, rep))
+		require.NoError(t, r.ResumeRelayer(ctx, rep))
+
+		time.Sleep(time.Duration(i%3) * time.Millisecond)
+		require.NoError(t, r.StopRelayer(ctx, rep))
+		start()
+	}
+	require.NoError(t, r.StopRelayer(ctx, rep))
+}
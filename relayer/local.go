@@ -0,0 +1,595 @@
+package relayer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/dockerutil"
+	"go.uber.org/zap"
+)
+
+// LocalRelayer provides a common base for relayer implementations
+// that run a relayer binary on the host, as subprocesses of the test.
+//
+// Commands are produced by the same RelayerCommander used by DockerRelayer.
+// A command whose first element is the commander's name runs the configured binary instead,
+// and the binary's directory is prepended to PATH, so that commands wrapped in a shell also find it.
+type LocalRelayer struct {
+	log *zap.Logger
+
+	// c defines all the commands to run on the host.
+	c RelayerCommander
+
+	home string
+	bin  string
+
+	testName string
+
+	// mu guards daemon, which MetricsURL reads while the metrics collector runs concurrently with the test.
+	// daemon is only written with mu held, so the test's own goroutine may read it without mu.
+	mu sync.Mutex
+
+	// The relayer process started by StartRelayer, and its output.
+	// Nil if the relayer is not running.
+	daemon *localDaemon
+
+	didInit bool
+
+	// wallets contains a mapping of chainID to relayer wallet
+	wallets map[string]ibc.RelayerWallet
+
+	// pathSrcChains contains a mapping of path name to the path's source chain ID
+	pathSrcChains map[string]string
+}
+
+// localDaemon is a relayer process started by StartRelayer.
+type localDaemon struct {
+	cmd       *exec.Cmd
+	startedAt time.Time
+
+	stdout, stderr bytes.Buffer
+
+	// Set if the output is being tracked line by line.
+	stdoutLog, stderrLog *logLineWriter
+
+	// Closed when the process exits; err is the result of waiting on the process.
+	done chan struct{}
+	err  error
+}
+
+var (
+	_ ibc.PathSharingRelayer = (*LocalRelayer)(nil)
+	_ ibc.MetricsRelayer     = (*LocalRelayer)(nil)
+)
+
+// NewLocalRelayer returns a new LocalRelayer that runs the relayer binary at bin.
+// If bin is not an absolute path, it is looked up in PATH.
+func NewLocalRelayer(log *zap.Logger, testName, home, bin string, c RelayerCommander, options ...RelayerOption) (*LocalRelayer, error) {
+	binPath, err := exec.LookPath(bin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find relayer binary: %w", err)
+	}
+	binPath, err = filepath.Abs(binPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve relayer binary path: %w", err)
+	}
+
+	r := &LocalRelayer{
+		log: log,
+
+		c: c,
+
+		home: home,
+		bin:  binPath,
+
+		testName: testName,
+
+		wallets: map[string]ibc.RelayerWallet{},
+
+		pathSrcChains: map[string]string{},
+	}
+
+	if err := os.MkdirAll(r.Dir(), 0755); err != nil {
+		return nil, fmt.Errorf("failed to initialize directory for relayer: %w", err)
+	}
+
+	return r, nil
+}
+
+func (r *LocalRelayer) AddChainConfiguration(ctx context.Context, rep ibc.RelayerExecReporter, chainConfig ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) error {
+	if !r.didInit {
+		if init := r.c.Init(r.Dir()); len(init) > 0 {
+			ctx, cancel := context.WithTimeout(ctx, time.Minute)
+			defer cancel()
+
+			exitCode, stdout, stderr, err := r.Exec(ctx, rep, init)
+			if err != nil {
+				return fmt.Errorf("relayer initialization failed: %w", dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err))
+			}
+		}
+
+		r.didInit = true
+	}
+
+	chainConfigFilePath := filepath.Join(r.Dir(), chainConfig.ChainID+".config")
+
+	configContent, err := r.c.ConfigContent(ctx, chainConfig, keyName, rpcAddr, grpcAddr)
+	if err != nil {
+		return fmt.Errorf("failed to generate config content: %w", err)
+	}
+
+	if err := os.WriteFile(chainConfigFilePath, configContent, 0644); err != nil {
+		return fmt.Errorf("failed to write config to disk: %w", err)
+	}
+
+	cmd := r.c.AddChainConfiguration(chainConfigFilePath, r.Dir())
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) AddKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName string) (ibc.RelayerWallet, error) {
+	cmd, err := r.c.AddKey(chainID, keyName, r.Dir())
+	if err != nil {
+		return ibc.RelayerWallet{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, cmd)
+	if err != nil {
+		return ibc.RelayerWallet{}, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	wallet, err := r.c.ParseAddKeyOutput(stdout, stderr)
+	if err != nil {
+		return ibc.RelayerWallet{}, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+	r.wallets[chainID] = wallet
+	return wallet, nil
+}
+
+func (r *LocalRelayer) GetWallet(chainID string) (ibc.RelayerWallet, bool) {
+	wallet, ok := r.wallets[chainID]
+	return wallet, ok
+}
+
+func (r *LocalRelayer) CreateChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	cmd := r.c.CreateChannel(pathName, opts, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) CloseChannel(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.CloseChannel(pathName, channelID, portID, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+// channelPortID returns the port of the channel with the given ID on the path's source chain.
+// The channel's port is needed to identify it, but it is not part of the path configuration.
+func (r *LocalRelayer) channelPortID(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) (string, error) {
+	srcChainID, ok := r.pathSrcChains[pathName]
+	if !ok {
+		return "", fmt.Errorf("path %s was never generated", pathName)
+	}
+
+	channels, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range channels {
+		if c.ChannelID == channelID {
+			return c.PortID, nil
+		}
+	}
+	return "", fmt.Errorf("channel %s not found on chain %s", channelID, srcChainID)
+}
+
+func (r *LocalRelayer) CreateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	cmd := r.c.CreateClients(pathName, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) CreateConnections(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	cmd := r.c.CreateConnections(pathName, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) FlushAcknowledgements(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.FlushAcknowledgements(pathName, channelID, portID, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) FlushPackets(ctx context.Context, rep ibc.RelayerExecReporter, pathName, channelID string) error {
+	portID, err := r.channelPortID(ctx, rep, pathName, channelID)
+	if err != nil {
+		return err
+	}
+
+	cmd := r.c.FlushPackets(pathName, channelID, portID, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) GeneratePath(ctx context.Context, rep ibc.RelayerExecReporter, srcChainID, dstChainID, pathName string, opts ibc.PathOptions) error {
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("invalid path options: %w", err)
+	}
+
+	cmd := r.c.GeneratePath(srcChainID, dstChainID, pathName, r.Dir())
+	updateCmd, err := r.c.UpdatePath(pathName, opts, r.Dir())
+	if err != nil {
+		return fmt.Errorf("failed to apply path options: %w", err)
+	}
+
+	if err := dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd)); err != nil {
+		return err
+	}
+
+	r.pathSrcChains[pathName] = srcChainID
+
+	if len(updateCmd) > 0 {
+		if err := dockerutil.HandleNodeJobError(r.Exec(ctx, rep, updateCmd)); err != nil {
+			return fmt.Errorf("failed to apply path options: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *LocalRelayer) GenerateSharedPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, src, dst ibc.PathEnd) error {
+	pathFilePath := filepath.Join(r.Dir(), pathName+".path")
+
+	content, err := r.c.SharedPathContent(src, dst)
+	if err != nil {
+		return fmt.Errorf("failed to generate path content: %w", err)
+	}
+	if len(content) > 0 {
+		if err := os.WriteFile(pathFilePath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write path to disk: %w", err)
+		}
+	}
+
+	cmd := r.c.GenerateSharedPath(pathName, src, dst, pathFilePath, r.Dir())
+	if err := dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd)); err != nil {
+		return err
+	}
+
+	r.pathSrcChains[pathName] = src.ChainID
+	return nil
+}
+
+func (r *LocalRelayer) GetChannels(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ChannelOutput, error) {
+	cmd := r.c.GetChannels(chainID, r.Dir())
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, cmd)
+	if err != nil {
+		return nil, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	return r.c.ParseGetChannelsOutput(stdout, stderr)
+}
+
+func (r *LocalRelayer) GetConnections(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) (ibc.ConnectionOutputs, error) {
+	cmd := r.c.GetConnections(chainID, r.Dir())
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, cmd)
+	if err != nil {
+		return nil, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	return r.c.ParseGetConnectionsOutput(stdout, stderr)
+}
+
+func (r *LocalRelayer) GetClients(ctx context.Context, rep ibc.RelayerExecReporter, chainID string) ([]ibc.ClientOutput, error) {
+	cmd := r.c.GetClients(chainID, r.Dir())
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Minute)
+	defer cancel()
+
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, cmd)
+	if err != nil {
+		return nil, dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	return r.c.ParseGetClientsOutput(stdout, stderr)
+}
+
+func (r *LocalRelayer) LinkPath(ctx context.Context, rep ibc.RelayerExecReporter, pathName string, opts ibc.CreateChannelOptions) error {
+	cmd := r.c.LinkPath(pathName, r.Dir(), opts)
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) RestoreKey(ctx context.Context, rep ibc.RelayerExecReporter, chainID, keyName, mnemonic string) error {
+	cmd := r.c.RestoreKey(chainID, keyName, mnemonic, r.Dir())
+
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, cmd)
+	if err != nil {
+		return dockerutil.HandleNodeJobError(exitCode, stdout, stderr, err)
+	}
+
+	r.wallets[chainID] = ibc.RelayerWallet{
+		Mnemonic: mnemonic,
+		Address:  r.c.ParseRestoreKeyOutput(stdout, stdout),
+	}
+	return nil
+}
+
+func (r *LocalRelayer) UpdateClients(ctx context.Context, rep ibc.RelayerExecReporter, pathName string) error {
+	cmd := r.c.UpdateClients(pathName, r.Dir())
+	return dockerutil.HandleNodeJobError(r.Exec(ctx, rep, cmd))
+}
+
+func (r *LocalRelayer) StartRelayer(ctx context.Context, rep ibc.RelayerExecReporter, pathNames ...string) error {
+	if r.daemon != nil {
+		return fmt.Errorf("relayer is already running")
+	}
+
+	cmd := r.command(r.c.StartRelayer(r.Dir(), pathNames...))
+
+	// The process outlives the context passed to StartRelayer,
+	// and is stopped in StopRelayer.
+	d := &localDaemon{
+		cmd:  exec.Command(cmd[0], cmd[1:]...),
+		done: make(chan struct{}),
+	}
+	d.cmd.Dir = r.Dir()
+	d.cmd.Env = r.env()
+
+	var stdout, stderr io.Writer = &d.stdout, &d.stderr
+	if logRep, ok := rep.(ibc.RelayerLogReporter); ok {
+		logRep = &lockedLogReporter{rep: logRep}
+		name := r.Name() + "-" + strings.Join(pathNames, ".")
+		d.stdoutLog = newLogLineWriter(logRep, name, "stdout")
+		d.stderrLog = newLogLineWriter(logRep, name, "stderr")
+		stdout = io.MultiWriter(stdout, d.stdoutLog)
+		stderr = io.MultiWriter(stderr, d.stderrLog)
+	}
+	d.cmd.Stdout = stdout
+	d.cmd.Stderr = stderr
+
+	r.log.Info("Running command", zap.String("command", strings.Join(cmd, " ")))
+	d.startedAt = time.Now()
+	if err := d.cmd.Start(); err != nil {
+		return fmt.Errorf("starting relayer: %w", err)
+	}
+
+	go func() {
+		defer close(d.done)
+		d.err = d.cmd.Wait()
+	}()
+
+	r.setDaemon(d)
+	return nil
+}
+
+// lockedLogReporter serializes calls to rep,
+// as the standard output and standard error of a process are copied concurrently.
+type lockedLogReporter struct {
+	mu  sync.Mutex
+	rep ibc.RelayerLogReporter
+}
+
+func (r *lockedLogReporter) TrackRelayerLog(containerName, stream string, when time.Time, line string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rep.TrackRelayerLog(containerName, stream, when, line)
+}
+
+func (r *LocalRelayer) StopRelayer(ctx context.Context, rep ibc.RelayerExecReporter) error {
+	d := r.daemon
+	if d == nil {
+		return fmt.Errorf("relayer is not running")
+	}
+	r.setDaemon(nil)
+
+	// Give the relayer the same 30 seconds to shut down cleanly that DockerRelayer gives its container.
+	if err := d.cmd.Process.Signal(os.Interrupt); err != nil {
+		r.log.Info("Failed to interrupt relayer, killing it", zap.Error(err))
+		_ = d.cmd.Process.Kill()
+	}
+	timer := time.NewTimer(30 * time.Second)
+	defer timer.Stop()
+	select {
+	case <-d.done:
+	case <-timer.C:
+		_ = d.cmd.Process.Kill()
+		<-d.done
+	case <-ctx.Done():
+		_ = d.cmd.Process.Kill()
+		<-d.done
+		return fmt.Errorf("StopRelayer: %w", ctx.Err())
+	}
+
+	if d.stdoutLog != nil {
+		d.stdoutLog.Flush()
+		d.stderrLog.Flush()
+	}
+
+	var exitErr *exec.ExitError
+	if d.err != nil && !errors.As(d.err, &exitErr) {
+		return fmt.Errorf("StopRelayer: waiting for relayer: %w", d.err)
+	}
+
+	stdout := d.stdout.String()
+	stderr := d.stderr.String()
+	rep.TrackRelayerExec(
+		"",
+		d.cmd.Args,
+		stdout, stderr,
+		d.cmd.ProcessState.ExitCode(),
+		d.startedAt,
+		time.Now(),
+		nil,
+	)
+
+	r.log.Debug(
+		fmt.Sprintf("Stopped relayer process\nstdout:\n%s\nstderr:\n%s", stdout, stderr),
+		zap.String("relayer", r.Name()),
+	)
+	return nil
+}
+
+// Kill kills the relayer process started by StartRelayer, if it is still running.
+// Unlike StopRelayer, it neither reports nor returns the outcome.
+// It is meant to be registered as a test cleanup, so that the process never outlives the test.
+func (r *LocalRelayer) Kill() {
+	d := r.daemon
+	if d == nil {
+		return
+	}
+	r.setDaemon(nil)
+
+	_ = d.cmd.Process.Kill()
+	<-d.done
+}
+
+// setDaemon records d as the running relayer process.
+func (r *LocalRelayer) setDaemon(d *localDaemon) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.daemon = d
+}
+
+func (r *LocalRelayer) MetricsURL(ctx context.Context) (string, error) {
+	port, path := r.c.MetricsEndpoint()
+	r.mu.Lock()
+	d := r.daemon
+	r.mu.Unlock()
+	if port == "" || d == nil {
+		return "", nil
+	}
+
+	select {
+	case <-d.done:
+		return "", nil
+	default:
+	}
+
+	// The relayer listens directly on the host, on the port it would listen on in a container.
+	return "http://127.0.0.1:" + strings.TrimSuffix(port, "/tcp") + path, nil
+}
+
+// Exec runs cmd to completion as a subprocess, reporting it to rep.
+// A non-zero exit code is reported through exitCode rather than err.
+func (r *LocalRelayer) Exec(ctx context.Context, rep ibc.RelayerExecReporter, cmd []string) (
+	exitCode int,
+	stdout, stderr string,
+	err error,
+) {
+	startedAt := time.Now()
+	defer func() {
+		rep.TrackRelayerExec(
+			"",
+			cmd,
+			stdout, stderr,
+			exitCode,
+			startedAt, time.Now(),
+			err,
+		)
+	}()
+
+	argv := r.command(cmd)
+	r.log.Info("Running command", zap.String("command", strings.Join(argv, " ")))
+
+	c := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	c.Dir = r.Dir()
+	c.Env = r.env()
+
+	stdoutBuf := new(bytes.Buffer)
+	stderrBuf := new(bytes.Buffer)
+	c.Stdout = stdoutBuf
+	c.Stderr = stderrBuf
+
+	runErr := c.Run()
+	stdout = stdoutBuf.String()
+	stderr = stderrBuf.String()
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		return 1, stdout, stderr, ctx.Err()
+	case errors.As(runErr, &exitErr):
+		exitCode = exitErr.ExitCode()
+	case runErr != nil:
+		return 1, stdout, stderr, runErr
+	}
+
+	r.log.Debug(
+		fmt.Sprintf("stdout:\n%s\nstderr:\n%s", stdout, stderr),
+		zap.String("relayer", r.Name()),
+	)
+	return exitCode, stdout, stderr, nil
+}
+
+// command returns cmd with the commander's binary name replaced by the configured binary.
+func (r *LocalRelayer) command(cmd []string) []string {
+	if len(cmd) > 0 && cmd[0] == r.c.Name() {
+		cmd = append([]string{r.bin}, cmd[1:]...)
+	}
+	return cmd
+}
+
+// env returns the environment of the relayer's subprocesses,
+// with the directory of the configured binary first in PATH.
+// HOME is set to the relayer's directory, so that relayers which keep state under the home directory,
+// such as the hermes key store, neither read nor modify the files of the user running the tests.
+func (r *LocalRelayer) env() []string {
+	return append(os.Environ(),
+		"HOME="+r.Dir(),
+		"PATH="+filepath.Dir(r.bin)+string(os.PathListSeparator)+os.Getenv("PATH"),
+	)
+}
+
+// FreeLocalPort returns a TCP port that is currently free on the host,
+// so that relayers running on the host can serve on ports that do not collide with one another.
+func FreeLocalPort() (string, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", fmt.Errorf("failed to find a free port: %w", err)
+	}
+	defer l.Close()
+	return strconv.Itoa(l.Addr().(*net.TCPAddr).Port), nil
+}
+
+func (r *LocalRelayer) Name() string {
+	return r.c.Name() + "-" + dockerutil.SanitizeContainerName(r.testName)
+}
+
+// Dir is the directory where the relayer's files are stored.
+// It is passed to every command as the relayer's home directory.
+func (r *LocalRelayer) Dir() string {
+	return filepath.Join(r.home, r.Name())
+}
+
+// UseDockerNetwork reports false, as the relayer connects to the host-exposed ports of the chains.
+func (r *LocalRelayer) UseDockerNetwork() bool {
+	return false
+}
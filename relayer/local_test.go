@@ -0,0 +1,125 @@
+package relayer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// shCommander is a RelayerCommander whose relayer binary is sh.
+// Methods not overridden here panic, through the nil embedded interface.
+type shCommander struct {
+	RelayerCommander
+}
+
+func (shCommander) Name() string { return "sh" }
+
+func (shCommander) MetricsEndpoint() (port, path string) { return "5183/tcp", "/metrics" }
+
+func (shCommander) StartRelayer(homeDir string, pathNames ...string) []string {
+	return []string{"sh", "-c", "echo started; echo oops >&2; touch ready; exec sleep 60"}
+}
+
+type trackedExec struct {
+	ContainerName  string
+	Command        []string
+	Stdout, Stderr string
+	ExitCode       int
+	Err            error
+}
+
+type mockExecReporter struct {
+	mockLogReporter
+	execs []trackedExec
+}
+
+func (r *mockExecReporter) TrackRelayerExec(
+	containerName string,
+	command []string,
+	stdout, stderr string,
+	exitCode int,
+	startedAt, finishedAt time.Time,
+	err error,
+) {
+	r.execs = append(r.execs, trackedExec{
+		ContainerName: containerName,
+		Command:       command,
+		Stdout:        stdout,
+		Stderr:        stderr,
+		ExitCode:      exitCode,
+		Err:           err,
+	})
+}
+
+func TestLocalRelayer_Exec(t *testing.T) {
+	r, err := NewLocalRelayer(zap.NewNop(), t.Name(), t.TempDir(), "sh", shCommander{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rep := new(mockExecReporter)
+
+	// Commands run in the relayer's home directory.
+	exitCode, stdout, stderr, err := r.Exec(ctx, rep, []string{"sh", "-c", "pwd; echo bad >&2; exit 3"})
+	require.NoError(t, err)
+	require.Equal(t, 3, exitCode)
+	require.Equal(t, r.Dir()+"\n", stdout)
+	require.Equal(t, "bad\n", stderr)
+
+	require.Len(t, rep.execs, 1)
+	require.Equal(t, trackedExec{
+		Command:  []string{"sh", "-c", "pwd; echo bad >&2; exit 3"},
+		Stdout:   stdout,
+		Stderr:   stderr,
+		ExitCode: 3,
+	}, rep.execs[0])
+}
+
+func TestLocalRelayer_StartStop(t *testing.T) {
+	r, err := NewLocalRelayer(zap.NewNop(), t.Name(), t.TempDir(), "sh", shCommander{})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	rep := new(mockExecReporter)
+
+	url, err := r.MetricsURL(ctx)
+	require.NoError(t, err)
+	require.Empty(t, url, "relayer that is not running must not report metrics")
+
+	require.NoError(t, r.StartRelayer(ctx, rep, "p"))
+	require.Error(t, r.StartRelayer(ctx, rep, "p"), "relayer must not start twice")
+
+	url, err = r.MetricsURL(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "http://127.0.0.1:5183/metrics", url)
+
+	// Wait for the relayer to write its output before stopping it.
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(filepath.Join(r.Dir(), "ready"))
+		return err == nil
+	}, 10*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, r.StopRelayer(ctx, rep))
+	require.Error(t, r.StopRelayer(ctx, rep), "relayer must not stop twice")
+
+	require.Len(t, rep.execs, 1)
+	require.Equal(t, "started\n", rep.execs[0].Stdout)
+	require.Equal(t, "oops\n", rep.execs[0].Stderr)
+
+	require.Len(t, rep.lines, 2)
+	for _, l := range rep.lines {
+		require.Equal(t, r.Name()+"-p", l.ContainerName)
+		switch l.Stream {
+		case "stdout":
+			require.Equal(t, "started", l.Line)
+		case "stderr":
+			require.Equal(t, "oops", l.Line)
+		default:
+			t.Fatalf("unexpected stream %q", l.Stream)
+		}
+	}
+}
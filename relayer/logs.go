@@ -0,0 +1,66 @@
+package relayer
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// logLineWriter is an io.Writer that splits the output of a docker log stream into lines,
+// and tracks each complete line through a RelayerLogReporter.
+//
+// The log stream is expected to be requested with timestamps,
+// so that each line is prefixed with an RFC3339Nano timestamp and a space.
+type logLineWriter struct {
+	rep ibc.RelayerLogReporter
+
+	containerName string
+	stream        string
+
+	buf []byte
+}
+
+func newLogLineWriter(rep ibc.RelayerLogReporter, containerName, stream string) *logLineWriter {
+	return &logLineWriter{
+		rep:           rep,
+		containerName: containerName,
+		stream:        stream,
+	}
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.track(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush tracks any buffered output that was not terminated by a newline.
+func (w *logLineWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.track(string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (w *logLineWriter) track(line string) {
+	line = strings.TrimSuffix(line, "\r")
+
+	when := time.Now()
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		if ts, err := time.Parse(time.RFC3339Nano, line[:i]); err == nil {
+			when = ts
+			line = line[i+1:]
+		}
+	}
+
+	w.rep.TrackRelayerLog(w.containerName, w.stream, when, line)
+}
@@ -0,0 +1,53 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type trackedLine struct {
+	ContainerName, Stream string
+	When                  time.Time
+	Line                  string
+}
+
+type mockLogReporter struct {
+	lines []trackedLine
+}
+
+func (r *mockLogReporter) TrackRelayerLog(containerName, stream string, when time.Time, line string) {
+	r.lines = append(r.lines, trackedLine{
+		ContainerName: containerName,
+		Stream:        stream,
+		When:          when,
+		Line:          line,
+	})
+}
+
+func TestLogLineWriter(t *testing.T) {
+	rep := new(mockLogReporter)
+	w := newLogLineWriter(rep, "rly-path", "stdout")
+
+	// Lines may be split across writes.
+	_, err := w.Write([]byte("2022-06-01T12:00:00.5Z first line\n2022-06-01T12:00:01Z sec"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("ond line\r\nno timestamp"))
+	require.NoError(t, err)
+
+	require.Len(t, rep.lines, 2)
+	require.Equal(t, trackedLine{
+		ContainerName: "rly-path",
+		Stream:        "stdout",
+		When:          time.Date(2022, 6, 1, 12, 0, 0, 500_000_000, time.UTC),
+		Line:          "first line",
+	}, rep.lines[0])
+	require.Equal(t, "second line", rep.lines[1].Line)
+	require.Equal(t, time.Date(2022, 6, 1, 12, 0, 1, 0, time.UTC), rep.lines[1].When)
+
+	w.Flush()
+	require.Len(t, rep.lines, 3)
+	require.Equal(t, "no timestamp", rep.lines[2].Line)
+	require.False(t, rep.lines[2].When.IsZero())
+}
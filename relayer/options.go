@@ -50,3 +50,27 @@ func StartupFlags(flags ...string) RelayerOption {
 }
 
 func (opt RelayerOptionExtraStartFlags) relayerOption() {}
+
+// RelayerOptionMetrics enables the relayer's Prometheus metrics server,
+// so that the test harness can record the relayer's metrics while it is running.
+type RelayerOptionMetrics struct{}
+
+func Metrics() RelayerOption {
+	return RelayerOptionMetrics{}
+}
+
+func (opt RelayerOptionMetrics) relayerOption() {}
+
+// RelayerOptionLocalBinary runs the relayer from a binary on the host, instead of in a Docker container.
+type RelayerOptionLocalBinary struct {
+	// Path to the relayer binary, or its name to look up in PATH.
+	Path string
+}
+
+func LocalBinary(path string) RelayerOption {
+	return RelayerOptionLocalBinary{
+		Path: path,
+	}
+}
+
+func (opt RelayerOptionLocalBinary) relayerOption() {}
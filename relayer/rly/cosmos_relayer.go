@@ -1,14 +1,19 @@
-// Package rly provides an interface to the cosmos relayer running in a Docker container.
+// Package rly provides an interface to the cosmos relayer running in a Docker container or on the host.
 package rly
 
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	tmclient "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
 	"github.com/docker/docker/client"
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/relayer"
@@ -22,13 +27,7 @@ type CosmosRelayer struct {
 }
 
 func NewCosmosRelayer(log *zap.Logger, testName, home string, cli *client.Client, networkID string, options ...relayer.RelayerOption) *CosmosRelayer {
-	c := commander{log: log}
-	for _, opt := range options {
-		switch o := opt.(type) {
-		case relayer.RelayerOptionExtraStartFlags:
-			c.extraStartFlags = o.Flags
-		}
-	}
+	c := newCommander(log, options...)
 	r := &CosmosRelayer{
 		DockerRelayer: relayer.NewDockerRelayer(log, testName, home, cli, networkID, c, options...),
 	}
@@ -40,6 +39,43 @@ func NewCosmosRelayer(log *zap.Logger, testName, home string, cli *client.Client
 	return r
 }
 
+// LocalCosmosRelayer is the ibc.Relayer implementation for github.com/cosmos/relayer,
+// running a rly binary on the host, such as one built from a local checkout.
+type LocalCosmosRelayer struct {
+	// Embedded LocalRelayer so commands just work.
+	*relayer.LocalRelayer
+}
+
+// NewLocalCosmosRelayer returns a LocalCosmosRelayer that runs the rly binary at bin.
+func NewLocalCosmosRelayer(log *zap.Logger, testName, home, bin string, options ...relayer.RelayerOption) (*LocalCosmosRelayer, error) {
+	c := newCommander(log, options...)
+
+	// The debug server listens directly on the host, so it must not collide with other relayers.
+	var err error
+	if c.debugPort, err = relayer.FreeLocalPort(); err != nil {
+		return nil, err
+	}
+
+	r, err := relayer.NewLocalRelayer(log, testName, home, bin, c, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalCosmosRelayer{LocalRelayer: r}, nil
+}
+
+func newCommander(log *zap.Logger, options ...relayer.RelayerOption) commander {
+	c := commander{log: log, debugPort: defaultDebugPort}
+	for _, opt := range options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionExtraStartFlags:
+			c.extraStartFlags = o.Flags
+		case relayer.RelayerOptionMetrics:
+			c.metrics = true
+		}
+	}
+	return c
+}
+
 type CosmosRelayerChainConfigValue struct {
 	AccountPrefix  string  `json:"account-prefix"`
 	ChainID        string  `json:"chain-id"`
@@ -60,6 +96,18 @@ type CosmosRelayerChainConfig struct {
 	Value CosmosRelayerChainConfigValue `json:"value"`
 }
 
+// CosmosRelayerPath is the JSON representation of a path in the relayer's configuration.
+type CosmosRelayerPath struct {
+	Src *CosmosRelayerPathEnd `json:"src"`
+	Dst *CosmosRelayerPathEnd `json:"dst"`
+}
+
+type CosmosRelayerPathEnd struct {
+	ChainID      string `json:"chain-id"`
+	ClientID     string `json:"client-id,omitempty"`
+	ConnectionID string `json:"connection-id,omitempty"`
+}
+
 const (
 	DefaultContainerImage   = "ghcr.io/cosmos/relayer"
 	DefaultContainerVersion = "v2.0.0-rc1"
@@ -70,8 +118,12 @@ const (
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	// RC1 supports everything except submitting client misbehaviour
+	// and creating connections with a delay period.
+	m := relayer.FullCapabilities()
+	m[relayer.Misbehaviour] = false
+	m[relayer.ConnectionDelay] = false
+	return m
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
@@ -98,8 +150,18 @@ func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName,
 type commander struct {
 	log             *zap.Logger
 	extraStartFlags []string
+
+	// Whether to serve metrics from the debug server while relaying.
+	metrics bool
+
+	// Port of the debug server.
+	debugPort string
 }
 
+// defaultDebugPort is the container port of the relayer's debug server, which serves the metrics.
+// A relayer running on the host picks a free port instead.
+const defaultDebugPort = "5183"
+
 func (commander) Name() string {
 	return "rly"
 }
@@ -111,10 +173,17 @@ func (commander) AddChainConfiguration(containerFilePath, homeDir string) []stri
 	}
 }
 
-func (commander) AddKey(chainID, keyName, homeDir string) []string {
+func (commander) AddKey(chainID, keyName, homeDir string) ([]string, error) {
 	return []string{
 		"rly", "keys", "add", chainID, keyName,
 		"--home", homeDir,
+	}, nil
+}
+
+func (commander) CloseChannel(pathName, channelID, portID, homeDir string) []string {
+	return []string{
+		"rly", "tx", "channel-close", pathName, channelID, portID,
+		"--home", homeDir,
 	}
 }
 
@@ -144,14 +213,14 @@ func (commander) CreateConnections(pathName, homeDir string) []string {
 	}
 }
 
-func (commander) FlushAcknowledgements(pathName, channelID, homeDir string) []string {
+func (commander) FlushAcknowledgements(pathName, channelID, portID, homeDir string) []string {
 	return []string{
 		"rly", "tx", "relay-acks", pathName, channelID,
 		"--home", homeDir,
 	}
 }
 
-func (commander) FlushPackets(pathName, channelID, homeDir string) []string {
+func (commander) FlushPackets(pathName, channelID, portID, homeDir string) []string {
 	return []string{
 		"rly", "tx", "relay-pkts", pathName, channelID,
 		"--home", homeDir,
@@ -165,6 +234,14 @@ func (commander) GeneratePath(srcChainID, dstChainID, pathName, homeDir string)
 	}
 }
 
+func (commander) GenerateSharedPath(pathName string, src, dst ibc.PathEnd, containerFilePath, homeDir string) []string {
+	return []string{
+		"rly", "paths", "add", src.ChainID, dst.ChainID, pathName,
+		"--file", containerFilePath,
+		"--home", homeDir,
+	}
+}
+
 func (commander) GetChannels(chainID, homeDir string) []string {
 	return []string{
 		"rly", "q", "channels", chainID,
@@ -172,6 +249,13 @@ func (commander) GetChannels(chainID, homeDir string) []string {
 	}
 }
 
+func (commander) GetClients(chainID, homeDir string) []string {
+	return []string{
+		"rly", "q", "clients", chainID,
+		"--home", homeDir,
+	}
+}
+
 func (commander) GetConnections(chainID, homeDir string) []string {
 	return []string{
 		"rly", "q", "connections", chainID,
@@ -198,10 +282,15 @@ func (commander) RestoreKey(chainID, keyName, mnemonic, homeDir string) []string
 	}
 }
 
-func (c commander) StartRelayer(pathName, homeDir string) []string {
-	cmd := []string{
-		"rly", "start", pathName, "--debug",
+func (c commander) StartRelayer(homeDir string, pathNames ...string) []string {
+	cmd := []string{"rly", "start"}
+	cmd = append(cmd, pathNames...)
+	cmd = append(cmd,
+		"--debug",
 		"--home", homeDir,
+	)
+	if c.metrics {
+		cmd = append(cmd, "--debug-addr", "0.0.0.0:"+c.debugPort)
 	}
 	cmd = append(cmd, c.extraStartFlags...)
 	return cmd
@@ -214,6 +303,22 @@ func (commander) UpdateClients(pathName, homeDir string) []string {
 	}
 }
 
+func (commander) UpdatePath(pathName string, opts ibc.PathOptions, homeDir string) ([]string, error) {
+	if opts.ConnectionDelay > 0 {
+		// The relayer always creates connections without a delay period.
+		return nil, errors.New("rly does not support connection delays")
+	}
+	if opts.PacketFilter.Policy == ibc.NoPacketFilter {
+		return nil, nil
+	}
+	return []string{
+		"rly", "paths", "update", pathName,
+		"--filter-rule", string(opts.PacketFilter.Policy),
+		"--filter-channels", strings.Join(opts.PacketFilter.ChannelIDs, ","),
+		"--home", homeDir,
+	}, nil
+}
+
 func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName, rpcAddr, grpcAddr string) ([]byte, error) {
 	cosmosRelayerChainConfig := ChainConfigToCosmosRelayerChainConfig(cfg, keyName, rpcAddr, grpcAddr)
 	jsonBytes, err := json.Marshal(cosmosRelayerChainConfig)
@@ -223,6 +328,20 @@ func (commander) ConfigContent(ctx context.Context, cfg ibc.ChainConfig, keyName
 	return jsonBytes, nil
 }
 
+func (commander) SharedPathContent(src, dst ibc.PathEnd) ([]byte, error) {
+	return json.Marshal(CosmosRelayerPath{
+		Src: &CosmosRelayerPathEnd{ChainID: src.ChainID, ClientID: src.ClientID, ConnectionID: src.ConnectionID},
+		Dst: &CosmosRelayerPathEnd{ChainID: dst.ChainID, ClientID: dst.ClientID, ConnectionID: dst.ConnectionID},
+	})
+}
+
+func (c commander) MetricsEndpoint() (port, path string) {
+	if !c.metrics {
+		return "", ""
+	}
+	return c.debugPort + "/tcp", "/relayer/metrics"
+}
+
 func (commander) DefaultContainerImage() string {
 	return DefaultContainerImage
 }
@@ -282,6 +401,53 @@ func (c commander) ParseGetConnectionsOutput(stdout, stderr string) (ibc.Connect
 	return connections, nil
 }
 
+// clientStateCodec decodes the identified client states that rly prints when querying clients.
+var clientStateCodec = func() *codec.ProtoCodec {
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	tmclient.RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry)
+}()
+
+func (c commander) ParseGetClientsOutput(stdout, stderr string) ([]ibc.ClientOutput, error) {
+	var clients []ibc.ClientOutput
+	for _, client := range strings.Split(stdout, "\n") {
+		if strings.TrimSpace(client) == "" {
+			continue
+		}
+
+		var identified clienttypes.IdentifiedClientState
+		if err := clientStateCodec.UnmarshalJSON([]byte(client), &identified); err != nil {
+			c.log.Error(
+				"Error parsing client json",
+				zap.Error(err),
+			)
+
+			continue
+		}
+
+		cs, err := clienttypes.UnpackClientState(identified.ClientState)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unpack client state for %s: %w", identified.ClientId, err)
+		}
+		tmState, ok := cs.(*tmclient.ClientState)
+		if !ok {
+			// Only tendermint clients are created by the relayer.
+			continue
+		}
+
+		clients = append(clients, ibc.ClientOutput{
+			ID:             identified.ClientId,
+			ChainID:        tmState.ChainId,
+			TrustingPeriod: tmState.TrustingPeriod,
+			LatestHeight:   tmState.LatestHeight,
+			Frozen:         !tmState.FrozenHeight.IsZero(),
+		})
+	}
+
+	return clients, nil
+}
+
 func (commander) Init(homeDir string) []string {
 	return []string{
 		"rly", "config", "init",
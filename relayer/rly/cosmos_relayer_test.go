@@ -0,0 +1,52 @@
+package rly
+
+import (
+	"testing"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	tmclient "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestParseGetClientsOutput(t *testing.T) {
+	clientLine := func(clientID string, frozenHeight clienttypes.Height) string {
+		cs := tmclient.NewClientState(
+			"chain-b", tmclient.DefaultTrustLevel,
+			time.Hour, 2*time.Hour, 10*time.Second,
+			clienttypes.NewHeight(1, 42), commitmenttypes.GetSDKSpecs(), []string{"upgrade", "upgradedIBCState"},
+			false, false,
+		)
+		cs.FrozenHeight = frozenHeight
+
+		identified := clienttypes.NewIdentifiedClientState(clientID, cs)
+		bz, err := clientStateCodec.MarshalJSON(&identified)
+		require.NoError(t, err)
+		return string(bz)
+	}
+
+	stdout := clientLine("07-tendermint-0", clienttypes.ZeroHeight()) + "\n" +
+		"not json\n" +
+		clientLine("07-tendermint-1", clienttypes.NewHeight(0, 1)) + "\n"
+
+	clients, err := commander{log: zap.NewNop()}.ParseGetClientsOutput(stdout, "")
+	require.NoError(t, err)
+	require.Equal(t, []ibc.ClientOutput{
+		{
+			ID:             "07-tendermint-0",
+			ChainID:        "chain-b",
+			TrustingPeriod: time.Hour,
+			LatestHeight:   clienttypes.NewHeight(1, 42),
+		},
+		{
+			ID:             "07-tendermint-1",
+			ChainID:        "chain-b",
+			TrustingPeriod: time.Hour,
+			LatestHeight:   clienttypes.NewHeight(1, 42),
+			Frozen:         true,
+		},
+	}, clients)
+}
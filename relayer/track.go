@@ -0,0 +1,20 @@
+package relayer
+
+import (
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+)
+
+// TrackOperation reports an operation of a relayer that runs no container or process to rep.
+// The reported command is relayerName followed by command, a description of the operation.
+// If err is not nil, the operation is reported as failed with err.
+func TrackOperation(rep ibc.RelayerExecReporter, relayerName string, startedAt time.Time, stdout string, err error, command ...string) {
+	exitCode := 0
+	stderr := ""
+	if err != nil {
+		exitCode = 1
+		stderr = err.Error()
+	}
+	rep.TrackRelayerExec("", append([]string{relayerName}, command...), stdout, stderr, exitCode, startedAt, time.Now(), err)
+}
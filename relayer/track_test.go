@@ -0,0 +1,28 @@
+package relayer
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackOperation(t *testing.T) {
+	rep := new(mockExecReporter)
+
+	TrackOperation(rep, "inprocess", time.Now(), "ok", nil, "flush", "p")
+	failure := errors.New("boom")
+	TrackOperation(rep, "inprocess", time.Now(), "", failure, "flush", "p")
+
+	require.Len(t, rep.execs, 2)
+
+	require.Equal(t, []string{"inprocess", "flush", "p"}, rep.execs[0].Command)
+	require.Equal(t, "ok", rep.execs[0].Stdout)
+	require.Zero(t, rep.execs[0].ExitCode)
+	require.NoError(t, rep.execs[0].Err)
+
+	require.Equal(t, 1, rep.execs[1].ExitCode)
+	require.Equal(t, "boom", rep.execs[1].Stderr)
+	require.ErrorIs(t, rep.execs[1].Err, failure)
+}
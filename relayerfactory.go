@@ -8,6 +8,8 @@ import (
 	"github.com/strangelove-ventures/ibctest/ibc"
 	"github.com/strangelove-ventures/ibctest/label"
 	"github.com/strangelove-ventures/ibctest/relayer"
+	"github.com/strangelove-ventures/ibctest/relayer/hermes"
+	"github.com/strangelove-ventures/ibctest/relayer/inprocess"
 	"github.com/strangelove-ventures/ibctest/relayer/rly"
 	"go.uber.org/zap"
 )
@@ -40,7 +42,8 @@ type RelayerFactory interface {
 }
 
 // builtinRelayerFactory is the built-in relayer factory that understands
-// how to start the cosmos relayer in a docker container.
+// how to start the cosmos relayer or hermes in a docker container,
+// or the in-process relayer.
 type builtinRelayerFactory struct {
 	impl    ibc.RelayerImplementation
 	log     *zap.Logger
@@ -58,6 +61,14 @@ func (f builtinRelayerFactory) Build(
 	networkID string,
 	home string,
 ) ibc.Relayer {
+	if bin := f.localBinary(); bin != "" {
+		r, err := f.buildLocal(t, home, bin)
+		if err != nil {
+			t.Fatalf("failed to build local relayer: %v", err)
+		}
+		return r
+	}
+
 	switch f.impl {
 	case ibc.CosmosRly:
 		return rly.NewCosmosRelayer(
@@ -68,12 +79,67 @@ func (f builtinRelayerFactory) Build(
 			networkID,
 			f.options...,
 		)
+	case ibc.Hermes:
+		return hermes.NewHermesRelayer(
+			f.log,
+			t.Name(),
+			home,
+			cli,
+			networkID,
+			f.options...,
+		)
+	case ibc.InProcess:
+		return inprocess.NewInProcessRelayer(f.log)
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
 }
 
+// localBinary returns the path of the relayer binary to run on the host,
+// or an empty string if the relayer runs in Docker.
+func (f builtinRelayerFactory) localBinary() string {
+	for _, opt := range f.options {
+		switch o := opt.(type) {
+		case relayer.RelayerOptionLocalBinary:
+			return o.Path
+		}
+	}
+	return ""
+}
+
+// buildLocal returns a relayer that runs the binary at bin on the host.
+func (f builtinRelayerFactory) buildLocal(t *testing.T, home, bin string) (ibc.Relayer, error) {
+	switch f.impl {
+	case ibc.CosmosRly:
+		r, err := rly.NewLocalCosmosRelayer(f.log, t.Name(), home, bin, f.options...)
+		if err != nil {
+			return nil, err
+		}
+		// The relayer process is not tied to any container, so kill it if the test never stops it.
+		t.Cleanup(r.Kill)
+		return r, nil
+	case ibc.Hermes:
+		r, err := hermes.NewLocalHermesRelayer(f.log, t.Name(), home, bin, f.options...)
+		if err != nil {
+			return nil, err
+		}
+		t.Cleanup(r.Kill)
+		return r, nil
+	default:
+		return nil, fmt.Errorf("RelayerImplementation %v cannot run from a local binary", f.impl)
+	}
+}
+
 func (f builtinRelayerFactory) Name() string {
+	if f.localBinary() != "" {
+		switch f.impl {
+		case ibc.CosmosRly:
+			return "rly@local"
+		case ibc.Hermes:
+			return "hermes@local"
+		}
+	}
+
 	switch f.impl {
 	case ibc.CosmosRly:
 		// This is using the string "rly" instead of rly.ContainerImage
@@ -86,6 +152,16 @@ func (f builtinRelayerFactory) Name() string {
 			}
 		}
 		return "rly@" + rly.DefaultContainerVersion
+	case ibc.Hermes:
+		for _, opt := range f.options {
+			switch o := opt.(type) {
+			case relayer.RelayerOptionDockerImage:
+				return "hermes@" + o.DockerImage.Version
+			}
+		}
+		return "hermes@" + hermes.DefaultContainerVersion
+	case ibc.InProcess:
+		return "inprocess"
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -95,6 +171,10 @@ func (f builtinRelayerFactory) Labels() []label.Relayer {
 	switch f.impl {
 	case ibc.CosmosRly:
 		return []label.Relayer{label.Rly}
+	case ibc.Hermes:
+		return []label.Relayer{label.Hermes}
+	case ibc.InProcess:
+		return []label.Relayer{label.InProcess}
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
@@ -106,6 +186,10 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	switch f.impl {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
+	case ibc.Hermes:
+		return hermes.Capabilities()
+	case ibc.InProcess:
+		return inprocess.Capabilities()
 	default:
 		panic(fmt.Errorf("RelayerImplementation %v unknown", f.impl))
 	}
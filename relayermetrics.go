@@ -0,0 +1,78 @@
+package ibctest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// relayerMetricsScrapeRate is how often the metrics of running relayers are scraped.
+const relayerMetricsScrapeRate = time.Second
+
+// relayerMetricsScraper scrapes the Prometheus metrics of a relayer while it is running.
+type relayerMetricsScraper struct {
+	r      ibc.MetricsRelayer
+	client *http.Client
+}
+
+func (s relayerMetricsScraper) ScrapeMetrics(ctx context.Context) ([]blockdb.MetricSample, error) {
+	url, err := s.r.MetricsURL(ctx)
+	if err != nil || url == "" {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response from %s: %s", url, resp.Status)
+	}
+	return blockdb.ParseMetrics(resp.Body)
+}
+
+// trackRelayerMetrics saves the metrics of every relayer that serves them to the block database,
+// while the relayer is running, until Close is called.
+// This method is a nop if the block database is not in use.
+func (ic *Interchain) trackRelayerMetrics(ctx context.Context) error {
+	if ic.cs.testCase == nil {
+		return nil
+	}
+
+	ctx, ic.metricsCancel = context.WithCancel(ctx)
+	ic.metricsEg = new(errgroup.Group)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	for r, name := range ic.relayers {
+		mr, ok := r.(ibc.MetricsRelayer)
+		if !ok {
+			continue
+		}
+
+		relayerdb, err := ic.cs.testCase.AddRelayer(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to add relayer %s to database: %w", name, err)
+		}
+
+		log := ic.log.With(zap.String("relayer", name))
+		collector := blockdb.NewMetricsCollector(log, relayerMetricsScraper{r: mr, client: client}, relayerdb, relayerMetricsScrapeRate)
+		ic.metricsEg.Go(func() error {
+			collector.Collect(ctx)
+			return nil
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package ibctest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/strangelove-ventures/ibctest/ibc"
+	"github.com/strangelove-ventures/ibctest/internal/blockdb"
+	"github.com/stretchr/testify/require"
+)
+
+// metricsURLRelayer is an ibc.MetricsRelayer that only implements MetricsURL.
+type metricsURLRelayer struct {
+	ibc.Relayer
+
+	url string
+}
+
+func (r metricsURLRelayer) MetricsURL(ctx context.Context) (string, error) {
+	return r.url, nil
+}
+
+func TestRelayerMetricsScraper(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/metrics" {
+			http.NotFound(w, req)
+			return
+		}
+		fmt.Fprintln(w, "# TYPE relayer_packets_relayed counter")
+		fmt.Fprintln(w, `relayer_packets_relayed{channel="channel-0"} 2`)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+
+	t.Run("running", func(t *testing.T) {
+		s := relayerMetricsScraper{r: metricsURLRelayer{url: srv.URL + "/metrics"}, client: srv.Client()}
+		samples, err := s.ScrapeMetrics(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []blockdb.MetricSample{
+			{Name: "relayer_packets_relayed", Labels: map[string]string{"channel": "channel-0"}, Value: 2},
+		}, samples)
+	})
+
+	t.Run("not running", func(t *testing.T) {
+		s := relayerMetricsScraper{r: metricsURLRelayer{}, client: srv.Client()}
+		samples, err := s.ScrapeMetrics(ctx)
+		require.NoError(t, err)
+		require.Empty(t, samples)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		s := relayerMetricsScraper{r: metricsURLRelayer{url: srv.URL + "/missing"}, client: srv.Client()}
+		_, err := s.ScrapeMetrics(ctx)
+		require.Error(t, err)
+	})
+}
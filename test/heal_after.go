@@ -0,0 +1,25 @@
+package test
+
+import (
+	"context"
+	"time"
+)
+
+// HealAfter calls heal in the background once d has elapsed, such as to end a network fault after a fixed time.
+// The returned channel receives the error returned by heal, or the error of ctx if ctx is done before d elapses,
+// in which case heal is not called.
+func HealAfter(ctx context.Context, d time.Duration, heal func(ctx context.Context) error) <-chan error {
+	errCh := make(chan error, 1)
+	go func() {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+		case <-timer.C:
+			errCh <- heal(ctx)
+		}
+	}()
+	return errCh
+}
@@ -0,0 +1,48 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		start := time.Now()
+		var healedAt time.Time
+		errCh := HealAfter(context.Background(), 10*time.Millisecond, func(ctx context.Context) error {
+			healedAt = time.Now()
+			return nil
+		})
+
+		require.NoError(t, <-errCh)
+		require.GreaterOrEqual(t, healedAt.Sub(start), 10*time.Millisecond)
+	})
+
+	t.Run("heal error", func(t *testing.T) {
+		errCh := HealAfter(context.Background(), 0, func(ctx context.Context) error {
+			return errors.New("boom")
+		})
+
+		require.EqualError(t, <-errCh, "boom")
+	})
+
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		called := false
+		errCh := HealAfter(ctx, time.Hour, func(ctx context.Context) error {
+			called = true
+			return nil
+		})
+
+		require.ErrorIs(t, <-errCh, context.Canceled)
+		require.False(t, called)
+	})
+}
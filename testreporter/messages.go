@@ -142,6 +142,27 @@ func (m RelayerExecMessage) typ() string {
 	return "RelayerExec"
 }
 
+// RelayerLogMessage is a single line of output from a long-running relayer process,
+// such as the relayer container started through StartRelayer.
+// This message is populated through the RelayerExecReporter type,
+// which is returned by the Reporter's RelayerExecReporter method.
+type RelayerLogMessage struct {
+	Name string // Test name, but "Name" for consistency.
+
+	When time.Time
+
+	ContainerName string `json:",omitempty"`
+
+	// Either "stdout" or "stderr".
+	Stream string
+
+	Line string
+}
+
+func (m RelayerLogMessage) typ() string {
+	return "RelayerLog"
+}
+
 // WrappedMessage wraps a Message with an outer Type field
 // so that decoders can determine the underlying message's type.
 type WrappedMessage struct {
@@ -208,6 +229,10 @@ func (m *WrappedMessage) UnmarshalJSON(b []byte) error {
 		x := RelayerExecMessage{}
 		err = json.Unmarshal(raw, &x)
 		msg = x
+	case "RelayerLog":
+		x := RelayerLogMessage{}
+		err = json.Unmarshal(raw, &x)
+		msg = x
 	default:
 		return fmt.Errorf("unknown message type %q", outer.Type)
 	}
@@ -45,6 +45,15 @@ func TestWrappedMessage_RoundTrip(t *testing.T) {
 				Error:         "",
 			},
 		},
+		{
+			Message: testreporter.RelayerLogMessage{
+				Name:          "foo",
+				When:          time.Now(),
+				ContainerName: "relayer-123",
+				Stream:        "stderr",
+				Line:          "relayed 1 packet",
+			},
+		},
 	}
 
 	for _, tc := range tcs {
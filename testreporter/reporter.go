@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/strangelove-ventures/ibctest/label"
@@ -28,6 +29,12 @@ type Reporter struct {
 
 	in chan Message
 
+	// Relayer log streams may outlive the tests that started them,
+	// so their messages are dropped once the reporter is closed, rather than sent on the closed in channel.
+	// mu guards closed, and is held for reading while a relayer log message is sent.
+	mu     sync.RWMutex
+	closed bool
+
 	writerDone chan error
 }
 
@@ -64,6 +71,10 @@ func (r *Reporter) write() {
 // Close closes the reporter and blocks until its results are flushed
 // to the underlying writer.
 func (r *Reporter) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+
 	r.in <- FinishSuiteMessage{
 		FinishedAt: time.Now(),
 	}
@@ -188,6 +199,24 @@ func (r *RelayerExecReporter) TrackRelayerExec(
 	}
 }
 
+// TrackRelayerLog tracks a single line of output from a long-running relayer process.
+// Lines tracked after the reporter is closed are dropped.
+func (r *RelayerExecReporter) TrackRelayerLog(containerName, stream string, when time.Time, line string) {
+	r.r.mu.RLock()
+	defer r.r.mu.RUnlock()
+	if r.r.closed {
+		return
+	}
+
+	r.r.in <- RelayerLogMessage{
+		Name:          r.testName,
+		When:          when,
+		ContainerName: containerName,
+		Stream:        stream,
+		Line:          line,
+	}
+}
+
 // TestifyT returns a TestifyReporter which will track logged errors in test.
 // Typically you will use this with the New method on the require or assert package:
 //     req := require.New(reporter.TestifyT(t))
@@ -310,6 +310,60 @@ func TestReporter_RelayerExec(t *testing.T) {
 	require.Empty(t, diff)
 }
 
+func TestReporter_RelayerLog(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := &mockT{name: "my_test"}
+
+	r.TrackTest(mt)
+
+	when := time.Now()
+	r.RelayerExecReporter(mt).TrackRelayerLog("my_container", "stdout", when, "relayed 1 packet")
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 5)
+
+	diff := cmp.Diff(testreporter.RelayerLogMessage{
+		Name:          "my_test",
+		When:          when,
+		ContainerName: "my_container",
+		Stream:        "stdout",
+		Line:          "relayed 1 packet",
+	}, msgs[2].(testreporter.RelayerLogMessage))
+	require.Empty(t, diff)
+}
+
+func TestReporter_RelayerLogAfterClose(t *testing.T) {
+	t.Parallel()
+
+	buf := new(bytes.Buffer)
+	r := testreporter.NewReporter(nopCloser{Writer: buf})
+
+	mt := &mockT{name: "my_test"}
+
+	r.TrackTest(mt)
+	rep := r.RelayerExecReporter(mt)
+
+	mt.RunCleanups()
+
+	require.NoError(t, r.Close())
+
+	// A relayer whose log stream outlives the test suite must not panic the reporter.
+	require.NotPanics(t, func() {
+		rep.TrackRelayerLog("my_container", "stdout", time.Now(), "relayed 1 packet")
+	})
+
+	msgs := ReporterMessages(t, buf)
+	require.Len(t, msgs, 4)
+}
+
 // requireTimeInRange is a helper to assert that a time occurs between a given start and end.
 func requireTimeInRange(t *testing.T, actual, notBefore, notAfter time.Time) {
 	t.Helper()